@@ -1,20 +1,85 @@
 package excel
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
+
+// CellKind describes the content of a cell
+type CellKind int
+
+const (
+	// KindEmpty is a cell without value and style
+	KindEmpty CellKind = 1
+	// KindStyled is a cell without value, but with a style
+	KindStyled CellKind = 2
+	// KindValue is a cell with a value
+	KindValue CellKind = 3
+	// KindFormula is a cell with a formula
+	KindFormula CellKind = 4
+	// KindError is a cell with an excel error like #DIV/0!
+	KindError CellKind = 5
+)
 
 // Cell wraps a cell's value and its style in a struct
 type Cell struct {
 	Value       interface{}
 	Style       Style
+	Hyperlink   Hyperlink
+	kind        CellKind
 	coordinates Coordinates
+	pristine    bool
 }
 
-// Coordinates returns the coordinates associated with cell
-func (c *Cell) Coordinates() Coordinates {
-	if c.coordinates == (Coordinates{}) {
-		fmt.Println("Coordinates for Cell are not yet initialized, returning empty stuct")
-		return Coordinates{}
+// Hyperlink links a cell to an external url or a location in the workbook like Sheet1!A1
+type Hyperlink struct {
+	Target   string
+	External bool
+}
+
+// Constructors
+
+// NewCell returns a cell with value and style. The value is kept as is, even if it's empty
+func NewCell(value interface{}, style Style) Cell {
+	return Cell{Value: value, Style: style, kind: KindValue}
+}
+
+// NewEmptyCell returns a cell without value and style
+func NewEmptyCell() Cell {
+	return Cell{Style: NoStyle(), kind: KindEmpty}
+}
+
+// NewStyledCell returns a cell without value, but with style
+func NewStyledCell(style Style) Cell {
+	return Cell{Style: style, kind: KindStyled}
+}
+
+// NewFormulaCell returns a cell with formula and style. The leading = of formula is optional
+func NewFormulaCell(formula string, style Style) Cell {
+	return Cell{Value: FormulaCell{Formula: formula}, Style: style, kind: KindFormula}
+}
+
+// errorCodes are the errors excel shows as result of a formula
+var errorCodes = []string{"#NULL!", "#DIV/0!", "#VALUE!", "#REF!", "#NAME?", "#NUM!", "#N/A", "#GETTING_DATA", "#SPILL!", "#CALC!"}
+
+// NewErrorCell returns a cell with an excel error like #N/A. It's written as formula, that evaluates to the error,
+// because excelize can't write error typed cells
+func NewErrorCell(code string) Cell {
+	return Cell{Value: code, Style: NoStyle(), kind: KindError}
+}
+
+// isErrorCode returns true, if code is one of the errors of excel
+func isErrorCode(code string) bool {
+	for _, c := range errorCodes {
+		if strings.EqualFold(c, code) {
+			return true
+		}
 	}
+	return false
+}
+
+// Coordinates returns the coordinates associated with cell or empty coordinates, if they are not yet initialized
+func (c *Cell) Coordinates() Coordinates {
 	return c.coordinates
 }
 
@@ -24,9 +89,63 @@ func (c *Cell) ChangeStyle(style Style) *Cell {
 	return c
 }
 
+// Kind returns the kind of cell. Cells, that haven't been created by a constructor, derive their kind from their value.
+// Strings starting with = are formulas like the ones Formula returns, use NewCell to write them as text
+func (c *Cell) Kind() CellKind {
+	if c.kind != 0 {
+		return c.kind
+	}
+	switch c.Value {
+	case nil, DraftCell:
+		if c.Style != (Style{}) {
+			return KindStyled
+		}
+		return KindEmpty
+	case StyleCell:
+		return KindStyled
+	}
+	switch v := c.Value.(type) {
+	case FormulaCell, *FormulaCell:
+		return KindFormula
+	case string:
+		if strings.HasPrefix(v, "=") {
+			return KindFormula
+		}
+	}
+	return KindValue
+}
+
+// blank marks cells, whose value is an empty or whitespace string, as styled cells, so they don't overwrite the style
+// with an empty value
+func (c *Cell) blank() {
+	if str, ok := c.Value.(string); ok && c.kind == 0 && strings.TrimSpace(str) == "" {
+		c.kind = KindStyled
+	}
+}
+
+// literal marks cells without kind, whose value is a string starting with =, as values. Data from structs, row builders
+// and marshalers is written as text, only cells built by the caller, like the ones Formula returns, become formulas
+func (c *Cell) literal() {
+	if str, ok := c.Value.(string); ok && c.kind == 0 && strings.HasPrefix(str, "=") {
+		c.kind = KindValue
+	}
+}
+
+// formula returns the formula of cell without the leading = and its cached result
+func (c *Cell) formula() (string, interface{}) {
+	switch f := c.Value.(type) {
+	case FormulaCell:
+		return strings.TrimPrefix(f.Formula, "="), f.Result
+	case *FormulaCell:
+		return strings.TrimPrefix(f.Formula, "="), f.Result
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%v", c.Value), "="), nil
+}
+
 // HasValue returns true, if cell has a value
 func (c *Cell) HasValue() bool {
-	if c.Value == DraftCell || c.Value == StyleCell {
+	switch c.Kind() {
+	case KindEmpty, KindStyled:
 		return false
 	}
 	return true
@@ -2,7 +2,7 @@ package excel
 
 import (
 	"fmt"
-	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/xuri/excelize/v2"
 )
 
 // Coordinates wraps coordinates in a struct
@@ -10,25 +10,36 @@ type Coordinates struct {
 	Row, Column int
 }
 
-// ToString returns the coordinates as excelformatted string
+// ToString returns the coordinates as excelformatted string or an empty string, if they are invalid
 func (c Coordinates) String() string {
-	if c.Row == 0 || c.Column == 0 {
-		fmt.Printf("Coordinates: %d, %d -> 0 is not allowed, excel starts at 1\n", c.Column, c.Row)
-		return ""
+	str, _ := c.CellName()
+	return str
+}
+
+// CellName returns the coordinates as excelformatted string or an ErrInvalidCoordinates error
+func (c Coordinates) CellName() (string, error) {
+	if c.Row < 1 || c.Column < 1 {
+		return "", fmt.Errorf("%w: %d, %d -> excel starts at 1", ErrInvalidCoordinates, c.Column, c.Row)
 	}
 	str, err := excelize.CoordinatesToCellName(c.Column, c.Row)
 	if err != nil {
-		fmt.Println(err)
+		return "", fmt.Errorf("%w: %s", ErrInvalidCoordinates, err)
 	}
-	return str
+	return str, nil
 }
 
 // StringWithReference returns the coordinates as excelformatted string, which references to another sheet
 func (c Coordinates) StringWithReference(sheet string) string {
-	if sheet == "" {
-		return c.String()
+	str, _ := c.CellNameWithReference(sheet)
+	return str
+}
+
+// CellNameWithReference returns the coordinates as excelformatted string, which references to another sheet
+func (c Coordinates) CellNameWithReference(sheet string) (string, error) {
+	str, err := c.CellName()
+	if err != nil || sheet == "" {
+		return str, err
 	}
-	str := c.String()
 	ref := fmt.Sprintf("'%s'!", sheet)
-	return ref + str
+	return ref + str, nil
 }
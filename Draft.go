@@ -0,0 +1,250 @@
+package excel
+
+import "sort"
+
+// draft stores the cells of a sheet sparsely by row and column, both starting at 1.
+// Cells, that have never been set, don't allocate any memory
+type draft struct {
+	rows   map[int]map[int]Cell
+	height int
+}
+
+// get returns the cell at coord and true, if it has been set
+func (d *draft) get(coord Coordinates) (Cell, bool) {
+	cell, ok := d.rows[coord.Row][coord.Column]
+	return cell, ok
+}
+
+// set stores cell at coord and updates its coordinates. The cell is marked as changed
+func (d *draft) set(coord Coordinates, cell Cell) {
+	cell.pristine = false
+	d.put(coord, cell)
+}
+
+// load stores cell, that has been read from the excel file, at coord. It's left untouched on save until it's changed
+func (d *draft) load(coord Coordinates, cell Cell) {
+	cell.pristine = true
+	d.put(coord, cell)
+}
+
+// put stores cell at coord and updates its coordinates
+func (d *draft) put(coord Coordinates, cell Cell) {
+	if d.rows == nil {
+		d.rows = map[int]map[int]Cell{}
+	}
+	if d.rows[coord.Row] == nil {
+		d.rows[coord.Row] = map[int]Cell{}
+	}
+	cell.coordinates = coord
+	d.rows[coord.Row][coord.Column] = cell
+	if coord.Row > d.height {
+		d.height = coord.Row
+	}
+}
+
+// clearRow removes all cells of row
+func (d *draft) clearRow(row int) {
+	delete(d.rows, row)
+}
+
+// appendRow stores cells in a new row below the last row
+func (d *draft) appendRow(cells map[int]Cell) {
+	d.height++
+	for column, cell := range cells {
+		d.set(Coordinates{Row: d.height, Column: column}, cell)
+	}
+}
+
+// width returns the highest column of row, that contains a cell
+func (d *draft) width(row int) int {
+	max := 0
+	for column := range d.rows[row] {
+		if column > max {
+			max = column
+		}
+	}
+	return max
+}
+
+// maxWidth returns the highest column of all rows, that contains a cell
+func (d *draft) maxWidth() int {
+	max := 0
+	for row := range d.rows {
+		if w := d.width(row); w > max {
+			max = w
+		}
+	}
+	return max
+}
+
+// len returns the number of cells stored in d
+func (d *draft) len() int {
+	count := 0
+	for _, cells := range d.rows {
+		count += len(cells)
+	}
+	return count
+}
+
+// each calls fn for every stored cell, ordered by row and column, and stops at the first error
+func (d *draft) each(fn func(cell Cell) error) error {
+	rows := []int{}
+	for row := range d.rows {
+		rows = append(rows, row)
+	}
+	sort.Ints(rows)
+	for _, row := range rows {
+		columns := []int{}
+		for column := range d.rows[row] {
+			columns = append(columns, column)
+		}
+		sort.Ints(columns)
+		for _, column := range columns {
+			if err := fn(d.rows[row][column]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rowCells returns a copy of the cells stored in row by column
+func (d *draft) rowCells(row int) map[int]Cell {
+	cells := make(map[int]Cell, len(d.rows[row]))
+	for column, cell := range d.rows[row] {
+		cells[column] = cell
+	}
+	return cells
+}
+
+// denseRow returns the cells of row, gaps are filled with empty cells
+func (d *draft) denseRow(row int) []Cell {
+	return denseCells(row, d.rows[row])
+}
+
+// denseCells returns cells of row as slice up to its highest column, gaps are filled with empty cells
+func denseCells(row int, cells map[int]Cell) []Cell {
+	width := 0
+	for column := range cells {
+		if column > width {
+			width = column
+		}
+	}
+	dense := make([]Cell, 0, width)
+	for column := 1; column <= width; column++ {
+		cell, ok := cells[column]
+		if !ok {
+			cell = NewEmptyCell()
+			cell.coordinates = Coordinates{Row: row, Column: column}
+		}
+		dense = append(dense, cell)
+	}
+	return dense
+}
+
+// dense returns all rows of d, gaps are filled with empty cells
+func (d *draft) dense() [][]Cell {
+	rows := [][]Cell{}
+	for row := 1; row <= d.height; row++ {
+		rows = append(rows, d.denseRow(row))
+	}
+	return rows
+}
+
+// clone returns a deep copy of d
+func (d *draft) clone() draft {
+	c := draft{height: d.height}
+	for row, cells := range d.rows {
+		for column, cell := range cells {
+			if f, ok := cell.Value.(*FormulaCell); ok {
+				copied := *f
+				cell.Value = &copied
+			}
+			c.put(Coordinates{Row: row, Column: column}, cell)
+		}
+	}
+	return c
+}
+
+// move rebuilds d with every cell moved by fn. Cells for which fn returns false are dropped
+func (d *draft) move(fn func(coord Coordinates) (Coordinates, bool)) {
+	rows := d.rows
+	d.rows = map[int]map[int]Cell{}
+	for _, cells := range rows {
+		for _, cell := range cells {
+			coord, ok := fn(cell.coordinates)
+			if !ok {
+				continue
+			}
+			if coord != cell.coordinates {
+				cell.pristine = false
+			}
+			d.put(coord, cell)
+		}
+	}
+}
+
+// insertRows moves all rows starting at row at down by n
+func (d *draft) insertRows(at, n int) {
+	height := d.height
+	d.move(func(coord Coordinates) (Coordinates, bool) {
+		if coord.Row >= at {
+			coord.Row += n
+		}
+		return coord, true
+	})
+	if height >= at {
+		d.height = height + n
+	} else {
+		d.height = at + n - 1
+	}
+}
+
+// deleteRows removes the rows from at to at+n-1 and moves the rows below up
+func (d *draft) deleteRows(at, n int) {
+	height := d.height
+	d.move(func(coord Coordinates) (Coordinates, bool) {
+		switch {
+		case coord.Row >= at+n:
+			coord.Row -= n
+		case coord.Row >= at:
+			return coord, false
+		}
+		return coord, true
+	})
+	switch {
+	case height >= at+n:
+		d.height = height - n
+	case height >= at:
+		d.height = at - 1
+	default:
+		d.height = height
+	}
+}
+
+// insertColumns moves all columns starting at column at right by n
+func (d *draft) insertColumns(at, n int) {
+	height := d.height
+	d.move(func(coord Coordinates) (Coordinates, bool) {
+		if coord.Column >= at {
+			coord.Column += n
+		}
+		return coord, true
+	})
+	d.height = height
+}
+
+// deleteColumns removes the columns from at to at+n-1 and moves the columns to the right left
+func (d *draft) deleteColumns(at, n int) {
+	height := d.height
+	d.move(func(coord Coordinates) (Coordinates, bool) {
+		switch {
+		case coord.Column >= at+n:
+			coord.Column -= n
+		case coord.Column >= at:
+			return coord, false
+		}
+		return coord, true
+	})
+	d.height = height
+}
@@ -0,0 +1,97 @@
+package excel
+
+import "testing"
+
+func TestDraftSparse(t *testing.T) {
+	d := draft{}
+	d.set(Coordinates{Row: 2, Column: 16384}, NewCell("far", NoStyle()))
+	d.set(Coordinates{Row: 1000, Column: 1}, NewCell("low", NoStyle()))
+	if d.len() != 2 || len(d.rows) != 2 || d.height != 1000 {
+		t.Fatalf("len %d, rows %d, height %d", d.len(), len(d.rows), d.height)
+	}
+	if d.maxWidth() != 16384 || d.width(1000) != 1 || d.width(1) != 0 {
+		t.Errorf("widths %d, %d, %d", d.maxWidth(), d.width(1000), d.width(1))
+	}
+	if cell, ok := d.get(Coordinates{Row: 2, Column: 16384}); !ok || cell.Value != "far" || cell.coordinates != (Coordinates{Row: 2, Column: 16384}) {
+		t.Errorf("get: %v, %v", cell, ok)
+	}
+	if _, ok := d.get(Coordinates{Row: 2, Column: 1}); ok {
+		t.Error("get: gap is stored")
+	}
+	row := d.denseRow(1000)
+	if len(row) != 1 || row[0].Value != "low" {
+		t.Errorf("denseRow: %v", row)
+	}
+	if row := d.denseRow(3); len(row) != 0 {
+		t.Errorf("denseRow of empty row: %v", row)
+	}
+}
+
+func TestDraftShift(t *testing.T) {
+	d := draft{}
+	d.set(Coordinates{Row: 1, Column: 1}, NewCell(1, NoStyle()))
+	d.set(Coordinates{Row: 3, Column: 2}, NewCell(3, NoStyle()))
+	d.insertRows(2, 2)
+	if cell, ok := d.get(Coordinates{Row: 5, Column: 2}); !ok || cell.Value != 3 || d.height != 5 {
+		t.Errorf("insertRows: %v, %v, height %d", cell, ok, d.height)
+	}
+	d.deleteRows(1, 2)
+	if cell, ok := d.get(Coordinates{Row: 3, Column: 2}); !ok || cell.Value != 3 || d.height != 3 || d.len() != 1 {
+		t.Errorf("deleteRows: %v, %v, height %d, len %d", cell, ok, d.height, d.len())
+	}
+	d.insertColumns(1, 3)
+	if cell, ok := d.get(Coordinates{Row: 3, Column: 5}); !ok || cell.Value != 3 {
+		t.Errorf("insertColumns: %v, %v", cell, ok)
+	}
+	d.deleteColumns(5, 1)
+	if d.len() != 0 || d.height != 3 {
+		t.Errorf("deleteColumns: len %d, height %d", d.len(), d.height)
+	}
+}
+
+func TestSheetSparseRows(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	far := Coordinates{Row: 1, Column: 10000}
+	if err := sh.SetValue(far, "far"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.SetValue(Coordinates{Row: 3, Column: 2}, 5); err != nil {
+		t.Fatal(err)
+	}
+	if cells := sh.Cells(); len(cells) != 2 || cells[0].Coordinates() != far {
+		t.Errorf("Cells: %v", cells)
+	}
+	rows, err := sh.Rows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		row, err := rows.Row()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(row.sparse) > 1 || row.cells != nil {
+			t.Errorf("row %d isn't sparse: %d cells", row.Index(), len(row.cells))
+		}
+		if row.Index() == 1 && row.Cell(far.Column).Value != "far" {
+			t.Errorf("row 1: %v", row.Cell(far.Column))
+		}
+		if cell := row.Cell(2); row.Index() == 3 && (cell.Value != 5 || cell.Coordinates() != (Coordinates{Row: 3, Column: 2})) {
+			t.Errorf("row 3: %v", cell)
+		}
+		if cell := row.Cell(1); cell.Kind() != KindEmpty || cell.Coordinates() != (Coordinates{Row: row.Index(), Column: 1}) {
+			t.Errorf("row %d, gap: %v", row.Index(), cell)
+		}
+	}
+	if rows.Index() != 3 {
+		t.Errorf("iterated %d rows, want 3", rows.Index())
+	}
+	if row, err := sh.Row(3); err != nil || len(row) != 2 || row[1].Value != 5 {
+		t.Errorf("Row(3): %v, %v", row, err)
+	}
+}
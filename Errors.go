@@ -0,0 +1,86 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors
+
+var (
+	// ErrNoWriteAccess is returned when modifying a sheet without write access
+	ErrNoWriteAccess = errors.New("no write access")
+	// ErrSheetNotFound is returned when a sheet doesn't exist in the excel file
+	ErrSheetNotFound = errors.New("sheet not found")
+	// ErrSheetExists is returned when a sheet with the same name already exists in the excel file
+	ErrSheetExists = errors.New("sheet already exists")
+	// ErrInvalidCoordinates is returned for coordinates, that don't point to a cell
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	// ErrColumnNotFound is returned when a column name isn't part of the header
+	ErrColumnNotFound = errors.New("column not found")
+	// ErrNoHeader is returned for sheets without a header row
+	ErrNoHeader = errors.New("no header")
+	// ErrInvalidSheetName is returned for names, that break excel's rules for sheet names
+	ErrInvalidSheetName = errors.New("invalid sheet name")
+	// ErrLastVisibleSheet is returned when deleting or hiding the last visible sheet of an excel file
+	ErrLastVisibleSheet = errors.New("last visible sheet")
+	// ErrTypeMismatch is returned when the value of a cell can't be converted to the requested type
+	ErrTypeMismatch = errors.New("type mismatch")
+)
+
+// SheetError records a problem with a single sheet
+type SheetError struct {
+	Sheet string
+	Err   error
+}
+
+func (e *SheetError) Error() string {
+	return fmt.Sprintf("sheet %s: %s", e.Sheet, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *SheetError) Unwrap() error {
+	return e.Err
+}
+
+// CellError records a problem with a single cell. Header is the name of the header column of the cell, if it's known
+type CellError struct {
+	Sheet       string
+	Coordinates Coordinates
+	Header      string
+	Value       interface{}
+	Err         error
+}
+
+func (e *CellError) Error() string {
+	if e.Header != "" {
+		return fmt.Sprintf("sheet %s, cell %s (%s): %s", e.Sheet, e.Coordinates.String(), e.Header, e.Err)
+	}
+	return fmt.Sprintf("sheet %s, cell %s: %s", e.Sheet, e.Coordinates.String(), e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// CellErrors collects the problems with multiple cells
+type CellErrors []*CellError
+
+func (e CellErrors) Error() string {
+	messages := []string{}
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d cells failed:\n%s", len(e), strings.Join(messages, "\n"))
+}
+
+// Unwrap returns the errors of all cells
+func (e CellErrors) Unwrap() []error {
+	errs := []error{}
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
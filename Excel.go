@@ -1,107 +1,362 @@
 package excel
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"time"
 
-	"github.com/360EntSecGroup-Skylar/excelize"
-	"github.com/schollz/progressbar"
+	"github.com/xuri/excelize/v2"
 )
 
 const (
-	// DraftCell is a placeholder for empty cells
+	// DraftCell is a placeholder for empty cells.
+	//
+	// Deprecated: use NewEmptyCell, only cells without a kind are checked for this value
 	DraftCell = "DRAFT_CELL"
-	// StyleCell is a placeholder for empty cells with a style
+	// StyleCell is a placeholder for empty cells with a style.
+	//
+	// Deprecated: use NewStyledCell, only cells without a kind are checked for this value
 	StyleCell = "STYLE_CELL"
 )
 
 // Excel wraps the excelize package
 type Excel struct {
-	file   *excelize.File
-	sheets *[]Sheet
+	file       *excelize.File
+	sheets     []*Sheet
+	options    Options
+	styles     map[Style]int
+	streams    []*StreamSheet
+	loadErrors []error
 }
 
-// File opens/creates a Excel file. If newly created, names the first sheet after sheetname
+// File opens/creates a Excel file. If newly created, names the first sheet after sheetname.
+// With override, an existing file at path is replaced on save, set Options.Backup to keep a copy of it
 func File(path string, sheetname string, override bool) *Excel {
-	var eFile *excelize.File
-	var sheets []Sheet
 	if _, err := os.Stat(path); os.IsNotExist(err) || override {
-		fmt.Printf("creating new file at path\n%s\n", path)
-		eFile = excelize.NewFile()
-		newExcel := Excel{file: eFile, sheets: &[]Sheet{}}
-		newExcel.Sheet(sheetname)
-		newExcel.file.DeleteSheet("Sheet1")
-		return &newExcel
-	}
-	fmt.Printf("found file at path %s\n", path)
+		excel, _ := Create(sheetname)
+		return excel
+	}
+	excel, _ := Open(path)
+	return excel
+}
+
+// Create creates a new Excel file and names the first sheet after sheetname
+func Create(sheetname string) (*Excel, error) {
+	excel := &Excel{file: excelize.NewFile()}
+	if sheetname != "Sheet1" {
+		if _, err := excel.file.NewSheet(sheetname); err != nil {
+			return nil, &SheetError{Sheet: sheetname, Err: err}
+		}
+		if err := excel.file.DeleteSheet("Sheet1"); err != nil {
+			return nil, &SheetError{Sheet: "Sheet1", Err: err}
+		}
+	}
+	excel.Sheet(sheetname)
+	return excel, nil
+}
+
+// Open opens the Excel file at path
+func Open(path string) (*Excel, error) {
 	eFile, err := excelize.OpenFile(path)
-	sheetMap := eFile.GetSheetMap()
-	for _, name := range sheetMap {
-		rows, _ := eFile.GetRows(name)
-		header := rows[0]
-		sheets = append(sheets, Sheet{file: eFile, name: name, columns: header, writeAccess: false})
+	if err != nil {
+		return nil, err
 	}
+	return newExcel(eFile)
+}
+
+// OpenReader opens the Excel file provided by r
+func OpenReader(r io.Reader) (*Excel, error) {
+	eFile, err := excelize.OpenReader(r)
 	if err != nil {
-		fmt.Printf("couldn't open file at path\n%s\nerr: %s", path, err)
+		return nil, err
 	}
-	return &Excel{
-		file:   eFile,
-		sheets: &sheets,
+	return newExcel(eFile)
+}
+
+// newExcel wraps eFile and its sheets into an Excel struct. Sheets, that can't be loaded, are reported by LoadErrors
+func newExcel(eFile *excelize.File) (*Excel, error) {
+	excel := &Excel{file: eFile}
+	for _, name := range eFile.GetSheetList() {
+		sheet := &Sheet{excel: excel, file: eFile, name: name, columns: []string{}, writeAccess: false}
+		rows, err := eFile.GetRows(name)
+		if err != nil {
+			excel.loadErrors = append(excel.loadErrors, &SheetError{Sheet: name, Err: err})
+		} else if !sheet.loadHeader(rows) {
+			excel.loadErrors = append(excel.loadErrors, &SheetError{Sheet: name, Err: ErrNoHeader})
+		}
+		excel.sheets = append(excel.sheets, sheet)
 	}
+	return excel, nil
+}
+
+// LoadErrors returns the problems, that occurred while loading the sheets of excel
+func (excel *Excel) LoadErrors() []error {
+	return excel.loadErrors
 }
 
 // Save saves the Excelfile to the provided path
 func (excel *Excel) Save(path string) {
-	fmt.Printf("attempting to write to %d sheets\n", len(*excel.sheets))
-	for _, sheet := range *excel.sheets {
+	if err := excel.SaveAs(path); err != nil {
+		excel.logger().Error("couldn't save excel file", "path", path, "err", err)
+	}
+}
+
+// SaveAs saves the Excelfile to the provided path. Sheets without write access are left untouched.
+// The file is written to a temporary file next to path first and then renamed, so path is never left half written
+func (excel *Excel) SaveAs(path string) error {
+	if err := excel.flush(); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := excel.file.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+		if excel.options.Backup {
+			if err := backup(path, time.Now(), mode); err != nil {
+				return err
+			}
+		}
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// backup copies the file at path to a timestamped .bak file with mode in the same directory. Backups within the same
+// millisecond get a counter suffix
+func backup(path string, now time.Time, mode os.FileMode) error {
+	stamp := fmt.Sprintf("%s.%s", path, now.Format("20060102-150405.000"))
+	for i := 1; ; i++ {
+		backupPath := stamp + ".bak"
+		if i > 1 {
+			backupPath = fmt.Sprintf("%s-%d.bak", stamp, i)
+		}
+		if err := copyFile(path, backupPath, mode); !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+	}
+}
+
+// copyFile links or copies the file at src to dst with mode. Returns an fs.ErrExist error, if dst exists
+func copyFile(src, dst string, mode os.FileMode) error {
+	err := os.Link(src, dst)
+	if err == nil || errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// WriteTo writes the Excelfile to w. Sheets without write access are left untouched
+func (excel *Excel) WriteTo(w io.Writer) (int64, error) {
+	if err := excel.flush(); err != nil {
+		return 0, err
+	}
+	return excel.file.WriteTo(w)
+}
+
+// Bytes returns the Excelfile as xlsx encoded bytes
+func (excel *Excel) Bytes() ([]byte, error) {
+	buf := bytes.Buffer{}
+	if _, err := excel.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// flush writes the drafts of all sheets with write access to the excel file
+func (excel *Excel) flush() error {
+	excel.logger().Debug("writing sheets", "count", len(excel.sheets))
+	for _, stream := range excel.streams {
+		if err := stream.Flush(); err != nil {
+			return err
+		}
+	}
+	for _, sheet := range excel.sheets {
 		if !sheet.writeAccess {
-			fmt.Printf("WARNING: didn't save. No write access for sheet %s\n", sheet.name)
+			excel.logger().Debug("skipping sheet without write access", "sheet", sheet.name)
 			continue
 		}
-		bar := progressbar.New(len(sheet.draft))
-		sheet.clearSheet()
-		currentCoords := Coordinates{Row: 0, Column: 0}
-		fmt.Printf("writing to sheet %s\n", sheet.name)
-		if len(sheet.columns) == 0 {
-			fmt.Printf("WARNING: Sheet %s has no header column\n", sheet.name)
-		}
-		for i, row := range sheet.draft {
-			for j, cell := range row {
-				bar.Add(1)
-				if cell.Value == DraftCell {
-					continue
-				}
-				if cell.Value == StyleCell {
-					cell.Value = " "
-				}
-				currentCoords.Row = i + 1
-				currentCoords.Column = j + 1
-				excel.file.SetCellValue(sheet.name, currentCoords.String(), cell.Value)
-
-				if isRaw, id := cell.Style.RawID(); isRaw {
-					excel.file.SetCellStyle(sheet.name, currentCoords.String(), currentCoords.String(), id)
-					continue
-				}
-
-				styleString := cell.Style.string()
-				if styleString == "" {
-					continue
-				}
-				st, err := excel.file.NewStyle(styleString)
-				if err != nil {
-					fmt.Println(styleString)
-					fmt.Println(err)
-				}
-				excel.file.SetCellStyle(sheet.name, currentCoords.String(), currentCoords.String(), st)
+		if err := excel.writeSheet(*sheet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSheet writes the draft of sheet to the excel file and reports its progress.
+// Cells are rewritten in place, so the layout of the sheet is kept. Cells that are no longer part of the draft are cleared
+func (excel *Excel) writeSheet(sheet Sheet) error {
+	excel.logger().Info("writing sheet", "sheet", sheet.name)
+	if len(sheet.columns) == 0 {
+		excel.logger().Warn("sheet has no header column", "sheet", sheet.name)
+	}
+	existing, err := excel.file.GetRows(sheet.name)
+	if err != nil {
+		return &SheetError{Sheet: sheet.name, Err: err}
+	}
+	total := sheet.draft.len()
+	done := 0
+	err = sheet.draft.each(func(cell Cell) error {
+		done++
+		excel.progress(sheet.name, done, total)
+		row, column := cell.coordinates.Row, cell.coordinates.Column
+		return excel.writeCell(sheet.name, cell, row <= len(existing) && column <= len(existing[row-1]))
+	})
+	if err != nil {
+		return err
+	}
+	// clear cells, that have been removed from the draft
+	for i, row := range existing {
+		for j := range row {
+			coords := Coordinates{Row: i + 1, Column: j + 1}
+			if _, ok := sheet.draft.get(coords); ok {
+				continue
+			}
+			if err := excel.clearCell(sheet.name, coords); err != nil {
+				return err
+			}
+		}
+	}
+	if sheet.freezeHeader {
+		split := sheet.lastHeaderRow()
+		if split == 0 {
+			split = 1
+		}
+		return sheet.file.SetPanes(sheet.name, &excelize.Panes{Freeze: true, YSplit: split, TopLeftCell: fmt.Sprintf("A%d", split+1), ActivePane: "bottomLeft"})
+	}
+	return nil
+}
+
+// writeCell writes value, style and hyperlink of cell to sheet. If the cell exists in the file, its content is cleared first.
+// Cells, that haven't changed since they have been loaded, are left untouched
+func (excel *Excel) writeCell(sheet string, cell Cell, exists bool) error {
+	if cell.pristine {
+		return nil
+	}
+	axis, err := cell.coordinates.CellName()
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := excel.clearCell(sheet, cell.coordinates); err != nil {
+			return err
+		}
+	}
+	if cell.Hyperlink.Target != "" {
+		linkType := "Location"
+		if cell.Hyperlink.External {
+			linkType = "External"
+		}
+		if err := excel.file.SetCellHyperLink(sheet, axis, cell.Hyperlink.Target, linkType); err != nil {
+			return &CellError{Sheet: sheet, Coordinates: cell.coordinates, Err: err}
+		}
+	}
+	switch cell.Kind() {
+	case KindEmpty:
+		return nil
+	case KindStyled:
+	case KindFormula:
+		formula, result := cell.formula()
+		// excelize keeps only numbers as cached result, when the formula is set after the value
+		if isNumber(result) {
+			if err := excel.file.SetCellValue(sheet, axis, result); err != nil {
+				return &CellError{Sheet: sheet, Coordinates: cell.coordinates, Err: err}
 			}
 		}
-		if sheet.freezeHeader {
-			sheet.file.SetPanes(sheet.name, `{"freeze":true,"split":false,"x_split":0,"y_split":1,"top_left_cell":"A34","active_pane":"bottomLeft"}`)
+		if err := excel.file.SetCellFormula(sheet, axis, formula); err != nil {
+			return &CellError{Sheet: sheet, Coordinates: cell.coordinates, Err: err}
+		}
+	case KindError:
+		if err := excel.file.SetCellFormula(sheet, axis, fmt.Sprintf("%v", cell.Value)); err != nil {
+			return &CellError{Sheet: sheet, Coordinates: cell.coordinates, Err: err}
+		}
+	default:
+		if err := excel.file.SetCellValue(sheet, axis, cell.Value); err != nil {
+			return &CellError{Sheet: sheet, Coordinates: cell.coordinates, Err: err}
 		}
-		fmt.Println()
-		fmt.Println()
 	}
 
-	excel.file.SaveAs(path)
-	println()
+	styleID, err := excel.StyleID(cell.Style)
+	if err != nil {
+		return &CellError{Sheet: sheet, Coordinates: cell.coordinates, Err: err}
+	}
+	if styleID == 0 {
+		return nil
+	}
+	if err := excel.file.SetCellStyle(sheet, axis, axis, styleID); err != nil {
+		return &CellError{Sheet: sheet, Coordinates: cell.coordinates, Err: err}
+	}
+	return nil
+}
+
+// clearCell removes value, formula and hyperlink of the cell at axis, but keeps its style. Empty cells are left untouched
+func (excel *Excel) clearCell(sheet string, coord Coordinates) error {
+	axis, err := coord.CellName()
+	if err != nil {
+		return err
+	}
+	formula, err := excel.file.GetCellFormula(sheet, axis)
+	if err != nil {
+		return &CellError{Sheet: sheet, Coordinates: coord, Err: err}
+	}
+	value, err := excel.file.GetCellValue(sheet, axis, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return &CellError{Sheet: sheet, Coordinates: coord, Err: err}
+	}
+	hasLink, _, err := excel.file.GetCellHyperLink(sheet, axis)
+	if err != nil {
+		return &CellError{Sheet: sheet, Coordinates: coord, Err: err}
+	}
+	if hasLink {
+		if err := excel.file.SetCellHyperLink(sheet, axis, "", "None"); err != nil {
+			return &CellError{Sheet: sheet, Coordinates: coord, Err: err}
+		}
+	}
+	if formula == "" && value == "" {
+		return nil
+	}
+	if formula != "" {
+		if err := excel.file.SetCellFormula(sheet, axis, ""); err != nil {
+			return &CellError{Sheet: sheet, Coordinates: coord, Err: err}
+		}
+	}
+	if err := excel.file.SetCellValue(sheet, axis, nil); err != nil {
+		return &CellError{Sheet: sheet, Coordinates: coord, Err: err}
+	}
+	return nil
 }
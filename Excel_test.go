@@ -0,0 +1,110 @@
+package excel
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// dirEntries returns the sorted names of the files in dir
+func dirEntries(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestSaveAs(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.xlsx")
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	excel.Sheet("S").AddRow(map[int]Cell{1: NewCell("first", NoStyle())})
+	if err := excel.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	if names := dirEntries(t, dir); len(names) != 1 || names[0] != "out.xlsx" {
+		t.Fatalf("files after first save: %v", names)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0644 {
+		t.Errorf("new file mode %v, want 0644", info.Mode().Perm())
+	}
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	excel.SetOptions(Options{Backup: true})
+	excel.Sheet("S").SetValue(Coordinates{Row: 1, Column: 1}, "second")
+	if err := excel.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	names := dirEntries(t, dir)
+	if len(names) != 2 || names[0] != "out.xlsx" || filepath.Ext(names[1]) != ".bak" {
+		t.Fatalf("files after second save: %v", names)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("saved file mode %v, want 0600", info.Mode().Perm())
+	}
+	if backup, _ := os.ReadFile(filepath.Join(dir, names[1])); !bytes.Equal(backup, first) {
+		t.Errorf("backup %s differs from the previous file", names[1])
+	}
+	saved, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := saved.file.GetCellValue("S", "A1"); value != "second" {
+		t.Errorf("saved A1 %q, want second", value)
+	}
+
+	if err := excel.SaveAs(filepath.Join(dir, "missing", "out.xlsx")); err == nil {
+		t.Error("saving to a missing directory: got no error")
+	}
+	if names := dirEntries(t, dir); len(names) != 2 {
+		t.Errorf("files after failed save: %v", names)
+	}
+}
+
+func TestBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.xlsx")
+	if err := os.WriteFile(path, []byte("content"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 31, 12, 30, 45, 123000000, time.Local)
+	for i := 0; i < 3; i++ {
+		if err := backup(path, now, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"out.xlsx", "out.xlsx.20240131-123045.123-2.bak", "out.xlsx.20240131-123045.123-3.bak", "out.xlsx.20240131-123045.123.bak"}
+	names := dirEntries(t, dir)
+	if len(names) != len(want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	for i, name := range names {
+		if name != want[i] {
+			t.Fatalf("got %v, want %v", names, want)
+		}
+		info, _ := os.Stat(filepath.Join(dir, name))
+		content, _ := os.ReadFile(filepath.Join(dir, name))
+		if info.Mode().Perm() != 0600 || string(content) != "content" {
+			t.Errorf("%s: mode %v, content %q", name, info.Mode().Perm(), content)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Match is a cell, that has been found by a search
+type Match struct {
+	Sheet       string
+	Coordinates Coordinates
+	Value       interface{}
+}
+
+// Matcher reports whether the value of a cell matches a search. Formulas are represented by their cached result,
+// empty cells by nil
+type Matcher func(value interface{}) bool
+
+// Matchers
+
+// MatchExact returns a matcher for values equal to value. Numbers are compared by their value, regardless of their type
+func MatchExact(value interface{}) Matcher {
+	return func(v interface{}) bool {
+		if a, ok := numeric(v); ok {
+			b, ok := numeric(value)
+			return ok && a == b
+		}
+		if v == nil || value == nil {
+			return v == nil && value == nil
+		}
+		return fmt.Sprintf("%v", v) == fmt.Sprintf("%v", value)
+	}
+}
+
+// MatchContains returns a matcher for values, whose text contains substr. Dates are matched as RFC3339 text,
+// numbers without exponent
+func MatchContains(substr string) Matcher {
+	return func(v interface{}) bool {
+		return v != nil && strings.Contains(stringValue(v), substr)
+	}
+}
+
+// MatchRegexp returns a matcher for values, whose text matches re. Values are converted to text like MatchContains does
+func MatchRegexp(re *regexp.Regexp) Matcher {
+	return func(v interface{}) bool {
+		return v != nil && re.MatchString(stringValue(v))
+	}
+}
+
+// MatchBetween returns a matcher for numbers between min and max, including both
+func MatchBetween(min, max float64) Matcher {
+	return func(v interface{}) bool {
+		f, ok := numeric(v)
+		return ok && f >= min && f <= max
+	}
+}
+
+// MatchEmpty returns a matcher for empty cells
+func MatchEmpty() Matcher {
+	return func(v interface{}) bool {
+		return v == nil
+	}
+}
+
+// Find
+
+// Find returns all cells of all sheets of excel, that match m, ordered by sheet, row and column
+func (excel *Excel) Find(m Matcher) ([]Match, error) {
+	matches := []Match{}
+	for _, sheet := range excel.sheets {
+		found, err := sheet.Find(m)
+		if err != nil {
+			return matches, err
+		}
+		matches = append(matches, found...)
+	}
+	return matches, nil
+}
+
+// Find returns all cells of sheet, that match m, ordered by row and column. Sheets with write access are
+// searched in their draft, all others in the excel file. Numbers with a date or time number format are matched as time.Time.
+// Empty cells inside the used range of the sheet are visited, if m matches nil like MatchEmpty
+func (sh *Sheet) Find(m Matcher) ([]Match, error) {
+	matches := []Match{}
+	date1904 := sh.date1904()
+	visitEmpty := m(nil)
+	visit := func(coord Coordinates, value interface{}) {
+		if value == nil && !visitEmpty {
+			return
+		}
+		if dated, err := sh.dateValue(coord, value, date1904); err == nil {
+			value = dated
+		}
+		if m(value) {
+			matches = append(matches, Match{Sheet: sh.name, Coordinates: coord, Value: value})
+		}
+	}
+	if sh.writeAccess {
+		if !visitEmpty {
+			sh.draft.each(func(cell Cell) error {
+				visit(cell.coordinates, plainValue(cell))
+				return nil
+			})
+			return matches, nil
+		}
+		width := sh.draft.maxWidth()
+		for row := 1; row <= sh.draft.height; row++ {
+			for column := 1; column <= width; column++ {
+				coord := Coordinates{Row: row, Column: column}
+				cell, _ := sh.draft.get(coord)
+				visit(coord, plainValue(cell))
+			}
+		}
+		return matches, nil
+	}
+	rows, err := sh.file.GetRows(sh.name, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return matches, &SheetError{Sheet: sh.name, Err: err}
+	}
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	for r, row := range rows {
+		for c := 0; c < width; c++ {
+			coord := Coordinates{Row: r + 1, Column: c + 1}
+			if c >= len(row) || strings.TrimSpace(row[c]) == "" {
+				visit(coord, nil)
+				continue
+			}
+			axis, err := coord.CellName()
+			if err != nil {
+				return matches, err
+			}
+			cellType, err := sh.file.GetCellType(sh.name, axis)
+			if err != nil {
+				return matches, sh.cellError(coord, row[c], err)
+			}
+			visit(coord, typedValue(row[c], cellType))
+		}
+	}
+	return matches, nil
+}
+
+// numeric returns the value of a number or a numeric text like the raw values of an excel file
+func numeric(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	case nil:
+		return 0, false
+	}
+	if !isNumber(value) {
+		return 0, false
+	}
+	return toFloat(value), true
+}
@@ -0,0 +1,60 @@
+package excel
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestFind(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	sh.AddHeaderColumn([]string{"Customer", "Date", "Amount"})
+	sh.AddRow(map[int]Cell{
+		1: NewCell("ACME", NoStyle()),
+		2: NewCell(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), DateStyle()),
+		3: NewCell(1e6, NoStyle()),
+	})
+	sh.AddRow(map[int]Cell{2: NewCell("x", NoStyle())})
+	excel.Sheet("T").AddRow(map[int]Cell{1: NewCell("ACME", NoStyle())})
+
+	tests := []struct {
+		name string
+		m    Matcher
+		want []string
+	}{
+		{"contains", MatchContains("C"), []string{"S!A1", "S!A2", "T!A1"}},
+		{"contains number", MatchContains("1000000"), []string{"S!C2"}},
+		{"regexp date", MatchRegexp(regexp.MustCompile(`^2024-01-31T`)), []string{"S!B2"}},
+		{"exact", MatchExact("ACME"), []string{"S!A2", "T!A1"}},
+		{"exact number", MatchExact(1000000), []string{"S!C2"}},
+		{"between", MatchBetween(1, 2e6), []string{"S!C2"}},
+		{"empty", MatchEmpty(), []string{"S!A3", "S!C3"}},
+	}
+	for _, file := range []*Excel{excel, reopen(t, excel)} {
+		for _, tt := range tests {
+			matches, err := file.Find(tt.m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, match := range matches {
+				axis, _ := match.Coordinates.CellName()
+				got = append(got, match.Sheet+"!"+axis)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				continue
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+					break
+				}
+			}
+		}
+	}
+}
@@ -10,13 +10,45 @@ type Formula struct {
 	sheet  string
 }
 
+// FormulaCell is a cell value, that is written as formula instead of a string. Result is the optional cached result
+// of the formula, which is displayed until excel recalculates the workbook. Only numeric results are written to the
+// file, other results are dropped and excel shows the formula's value after recalculating
+type FormulaCell struct {
+	Formula string
+	Result  interface{}
+}
+
+// String returns the cached result of the formula or the formula itself, if there is no result
+func (f FormulaCell) String() string {
+	if f.Result == nil {
+		return f.Formula
+	}
+	return fmt.Sprintf("%v", f.Result)
+}
+
 // FormulaFromRange returns a Formula with all coordinates from start to end in sheet
 func FormulaFromRange(start, end Coordinates) *Formula {
+	formula, err := NewFormulaFromRange(start, end)
+	if err != nil {
+		return &Formula{Coords: &[]Coordinates{}, sheet: ""}
+	}
+	return formula
+}
+
+// NewFormulaFromRange returns a Formula with all coordinates from start to end or an ErrInvalidCoordinates error
+func NewFormulaFromRange(start, end Coordinates) (*Formula, error) {
 	coords := []Coordinates{}
 
+	startName, err := start.CellName()
+	if err != nil {
+		return nil, err
+	}
+	endName, err := end.CellName()
+	if err != nil {
+		return nil, err
+	}
 	if start.Row > end.Row || start.Column > end.Column {
-		fmt.Printf("Start coordinates ahead of end coordinates in range %s:%s\n", start.String(), end.String())
-		return &Formula{Coords: &coords, sheet: ""}
+		return nil, fmt.Errorf("%w: start coordinates ahead of end coordinates in range %s:%s", ErrInvalidCoordinates, startName, endName)
 	}
 	coordsMap := map[int][]int{}
 	for sC := start.Column; sC < end.Column+1; sC++ {
@@ -31,7 +63,7 @@ func FormulaFromRange(start, end Coordinates) *Formula {
 		}
 	}
 	coords = append(coords, end)
-	return &Formula{Coords: &coords}
+	return &Formula{Coords: &coords}, nil
 }
 
 // Reference makes the formula reference to another sheet
@@ -58,7 +90,7 @@ func (formula *Formula) Sum() string {
 		}
 	}
 
-	return fmt.Sprintf("=SUMME(%s:%s)", lowest.StringWithReference(formula.sheet), highest.StringWithReference(formula.sheet))
+	return fmt.Sprintf("=SUM(%s:%s)", lowest.StringWithReference(formula.sheet), highest.StringWithReference(formula.sheet))
 }
 
 // Add adds the coords
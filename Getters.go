@@ -0,0 +1,360 @@
+package excel
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// timeLayouts are tried in order to parse dates stored as text
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"02.01.2006 15:04:05",
+	"02.01.2006",
+	"01/02/2006 15:04:05",
+	"01/02/2006",
+	"1/2/06 15:04",
+	"1/2/06",
+	"15:04:05",
+	"15:04",
+}
+
+// Typed Getters
+
+// GetString returns the value of the cell at coord as text. Cells of sheets without write access are formatted
+// by their number format like excel displays them, empty cells return an empty string
+func (sh *Sheet) GetString(coord Coordinates) (string, error) {
+	axis, err := coord.CellName()
+	if err != nil {
+		return "", err
+	}
+	if !sh.writeAccess {
+		value, err := sh.file.GetCellValue(sh.name, axis)
+		if err != nil {
+			return "", sh.cellError(coord, nil, err)
+		}
+		return value, nil
+	}
+	value, err := sh.plainValueAt(coord)
+	if err != nil || value == nil {
+		return "", err
+	}
+	return stringValue(value), nil
+}
+
+// GetFloat returns the number in the cell at coord. Numeric text is converted, empty cells return 0
+func (sh *Sheet) GetFloat(coord Coordinates) (float64, error) {
+	value, err := sh.plainValueAt(coord)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	f, err := floatValue(value)
+	if err != nil {
+		return 0, sh.cellError(coord, value, err)
+	}
+	return f, nil
+}
+
+// GetInt returns the whole number in the cell at coord. Numbers with a fraction are a type mismatch, empty cells return 0
+func (sh *Sheet) GetInt(coord Coordinates) (int, error) {
+	value, err := sh.plainValueAt(coord)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	i, err := intValue(value)
+	if err != nil || int64(int(i)) != i {
+		return 0, sh.cellError(coord, value, mismatch(value, "whole number"))
+	}
+	return int(i), nil
+}
+
+// GetBool returns the boolean in the cell at coord. Text like TRUE or 0 is converted, empty cells return false
+func (sh *Sheet) GetBool(coord Coordinates) (bool, error) {
+	value, err := sh.plainValueAt(coord)
+	if err != nil || value == nil {
+		return false, err
+	}
+	b, err := boolValue(value)
+	if err != nil {
+		return false, sh.cellError(coord, value, err)
+	}
+	return b, nil
+}
+
+// GetTime returns the date in the cell at coord. Numbers with a date or time number format are converted from excel serial dates
+// in the date system of the excel file (1900 or 1904), other numbers are a type mismatch. Text is parsed in common date formats,
+// empty cells return the zero time
+func (sh *Sheet) GetTime(coord Coordinates) (time.Time, error) {
+	value, err := sh.plainValueAt(coord)
+	if err != nil || value == nil {
+		return time.Time{}, err
+	}
+	if value, err = sh.dateValue(coord, value, sh.date1904()); err != nil {
+		return time.Time{}, sh.cellError(coord, value, err)
+	}
+	t, err := timeValue(value)
+	if err != nil {
+		return time.Time{}, sh.cellError(coord, value, err)
+	}
+	return t, nil
+}
+
+// GetDecimal returns the number in the cell at coord as exact decimal. Values of sheets without write access are
+// taken from the excel file without rounding, empty cells return 0
+func (sh *Sheet) GetDecimal(coord Coordinates) (*big.Rat, error) {
+	var value interface{}
+	var err error
+	if sh.writeAccess {
+		value, err = sh.plainValueAt(coord)
+	} else {
+		var raw string
+		var cellType excelize.CellType
+		if raw, cellType, err = sh.rawValueAt(coord); err == nil && raw != "" {
+			value = typedValue(raw, cellType)
+			if _, isNumber := value.(float64); isNumber {
+				value = raw
+			}
+		}
+	}
+	if err != nil || value == nil {
+		return new(big.Rat), err
+	}
+	r, err := decimalValue(value)
+	if err != nil {
+		return new(big.Rat), sh.cellError(coord, value, err)
+	}
+	return r, nil
+}
+
+// Conversion
+
+// stringValue formats a plain value as text. Dates are formatted as RFC3339, numbers without exponent
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// floatValue converts a plain value or numeric text to a number
+func floatValue(value interface{}) (float64, error) {
+	if f, ok := numeric(value); ok {
+		return f, nil
+	}
+	return 0, mismatch(value, "number")
+}
+
+// intValue converts a plain value or numeric text to a whole number
+func intValue(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	}
+	f, ok := numeric(value)
+	if !ok || f != math.Trunc(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, mismatch(value, "whole number")
+	}
+	return int64(f), nil
+}
+
+// boolValue converts a boolean or text like TRUE or 0 to a boolean
+func boolValue(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return b, nil
+		}
+	}
+	return false, mismatch(value, "boolean")
+}
+
+// timeValue converts a date or date text to a time
+func timeValue(value interface{}) (time.Time, error) {
+	if t, ok := value.(time.Time); ok {
+		return t, nil
+	}
+	if str, ok := value.(string); ok {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(str)); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, mismatch(value, "date")
+}
+
+// decimalValue converts a plain value or numeric text to an exact decimal
+func decimalValue(value interface{}) (*big.Rat, error) {
+	text := ""
+	switch v := value.(type) {
+	case *big.Rat:
+		return new(big.Rat).Set(v), nil
+	case float32:
+		text = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		text = strings.TrimSpace(v)
+	default:
+		if isNumber(value) {
+			text = fmt.Sprintf("%d", value)
+		}
+	}
+	if r, ok := new(big.Rat).SetString(text); ok {
+		return r, nil
+	}
+	return nil, mismatch(value, "decimal")
+}
+
+// plainValueAt returns the plain value of the cell at coord. Values of sheets without write access are read unformatted
+// and converted by their cell type. Empty cells are nil
+func (sh *Sheet) plainValueAt(coord Coordinates) (interface{}, error) {
+	if _, err := coord.CellName(); err != nil {
+		return nil, err
+	}
+	if sh.writeAccess {
+		cell, _ := sh.draft.get(coord)
+		return plainValue(cell), nil
+	}
+	raw, cellType, err := sh.rawValueAt(coord)
+	if err != nil || raw == "" {
+		return nil, err
+	}
+	return typedValue(raw, cellType), nil
+}
+
+// rawValueAt returns the unformatted value of the cell at coord in the excel file and its cell type. Empty cells return an
+// empty string, error cells an ErrTypeMismatch error
+func (sh *Sheet) rawValueAt(coord Coordinates) (string, excelize.CellType, error) {
+	axis, err := coord.CellName()
+	if err != nil {
+		return "", excelize.CellTypeUnset, err
+	}
+	raw, err := sh.file.GetCellValue(sh.name, axis, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return "", excelize.CellTypeUnset, sh.cellError(coord, nil, err)
+	}
+	if strings.TrimSpace(raw) == "" {
+		return "", excelize.CellTypeUnset, nil
+	}
+	cellType, err := sh.file.GetCellType(sh.name, axis)
+	if err != nil {
+		return "", excelize.CellTypeUnset, sh.cellError(coord, raw, err)
+	}
+	if cellType == excelize.CellTypeError {
+		return "", cellType, sh.cellError(coord, raw, fmt.Errorf("%w: excel error %s", ErrTypeMismatch, raw))
+	}
+	return raw, cellType, nil
+}
+
+// dateValue converts value to a time, if it is a number in a cell with a date or time number format
+func (sh *Sheet) dateValue(coord Coordinates, value interface{}, date1904 bool) (interface{}, error) {
+	if _, isText := value.(string); isText {
+		return value, nil
+	}
+	serial, ok := numeric(value)
+	if !ok || !sh.dateFormatted(coord) {
+		return value, nil
+	}
+	t, err := excelize.ExcelDateToTime(serial, date1904)
+	if err != nil {
+		return value, fmt.Errorf("%w: %s", ErrTypeMismatch, err)
+	}
+	return t, nil
+}
+
+// dateFormatted returns true, if the cell at coord has a date or time number format
+func (sh *Sheet) dateFormatted(coord Coordinates) bool {
+	styleID := 0
+	if sh.writeAccess {
+		cell, _ := sh.draft.get(coord)
+		isRaw, id := cell.Style.RawID()
+		if !isRaw {
+			return cell.Style.Format == Date
+		}
+		styleID = id
+	} else {
+		axis, err := coord.CellName()
+		if err != nil {
+			return false
+		}
+		if styleID, err = sh.file.GetCellStyle(sh.name, axis); err != nil {
+			return false
+		}
+	}
+	style, err := sh.file.GetStyle(styleID)
+	if err != nil {
+		return false
+	}
+	if style.CustomNumFmt != nil {
+		return isDateFormatCode(*style.CustomNumFmt)
+	}
+	return isDateNumFmt(style.NumFmt)
+}
+
+// isDateNumFmt returns true, if id is one of the built-in date and time number formats of excel
+func isDateNumFmt(id int) bool {
+	return id >= 14 && id <= 22 || id >= 27 && id <= 36 || id >= 45 && id <= 47 || id >= 50 && id <= 58 || id >= 71 && id <= 81
+}
+
+// isDateFormatCode returns true, if the number format code contains date or time tokens outside of text, escapes and brackets.
+// Elapsed time like [h] counts as time
+func isDateFormatCode(code string) bool {
+	quoted := false
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		case c == '[':
+			end := strings.IndexByte(code[i:], ']')
+			if end == -1 {
+				return false
+			}
+			if section := strings.ToLower(code[i+1 : i+end]); section != "" && strings.Trim(section, "hms") == "" {
+				return true
+			}
+			i += end
+		case strings.IndexByte("dDmMyYhHsS", c) != -1:
+			return true
+		}
+	}
+	return false
+}
+
+// date1904 returns true, if the excel file of sheet uses the 1904 date system
+func (sh *Sheet) date1904() bool {
+	props, err := sh.file.GetWorkbookProps()
+	if err != nil || props.Date1904 == nil {
+		return false
+	}
+	return *props.Date1904
+}
+
+// mismatch returns an ErrTypeMismatch error for value, that isn't convertible to want
+func mismatch(value interface{}, want string) error {
+	return fmt.Errorf("%w: %v is not a %s", ErrTypeMismatch, value, want)
+}
+
+func (sh *Sheet) cellError(coord Coordinates, value interface{}, err error) error {
+	return &CellError{Sheet: sh.name, Coordinates: coord, Value: value, Err: err}
+}
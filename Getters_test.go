@@ -0,0 +1,85 @@
+package excel
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// reopen writes excel and opens the result, so its sheets are read from the file
+func reopen(t *testing.T, excel *Excel) *Excel {
+	t.Helper()
+	data, err := excel.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reopened
+}
+
+func TestGetTime(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	sh.AddRow(map[int]Cell{
+		1: NewCell(45000.5, DateStyle()),
+		2: NewCell(2.5, EuroStyle()),
+		3: NewCell(2.5, Style{}),
+		4: NewCell("2023-03-15", Style{}),
+	})
+	for _, sheet := range []*Sheet{sh, reopen(t, excel).Sheet("S")} {
+		tm, err := sheet.GetTime(Coordinates{Row: 1, Column: 1})
+		if err != nil || tm.Format("2006-01-02 15:04") != "2023-03-15 12:00" {
+			t.Errorf("date: got %v, %v", tm, err)
+		}
+		for column := 2; column <= 3; column++ {
+			if tm, err := sheet.GetTime(Coordinates{Row: 1, Column: column}); !errors.Is(err, ErrTypeMismatch) {
+				t.Errorf("column %d: got %v, %v, want ErrTypeMismatch", column, tm, err)
+			}
+		}
+		tm, err = sheet.GetTime(Coordinates{Row: 1, Column: 4})
+		if err != nil || !tm.Equal(time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("text: got %v, %v", tm, err)
+		}
+	}
+}
+
+func TestIsDateFormatCode(t *testing.T) {
+	tests := map[string]bool{
+		"dd.mm.yyyy":            true,
+		"[h]:mm":                true,
+		"[$-407]mmmm yyyy":      true,
+		`#,##0.00\ [$€-1]`:      false,
+		`0.00 "days"`:           false,
+		"[Red]#,##0.00":         false,
+		"General":               false,
+		`_-* #,##0.00\ "€"_-;@`: false,
+	}
+	for code, want := range tests {
+		if got := isDateFormatCode(code); got != want {
+			t.Errorf("isDateFormatCode(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestGetDecimal(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	excel.Sheet("S").AddRow(map[int]Cell{1: NewCell(0.1, Style{}), 2: NewCell(true, Style{})})
+	sheet := reopen(t, excel).Sheet("S")
+	d, err := sheet.GetDecimal(Coordinates{Row: 1, Column: 1})
+	if err != nil || d.RatString() != "1/10" {
+		t.Errorf("got %v, %v, want 1/10", d, err)
+	}
+	if d, err := sheet.GetDecimal(Coordinates{Row: 1, Column: 2}); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("bool: got %v, %v, want ErrTypeMismatch", d, err)
+	}
+}
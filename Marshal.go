@@ -0,0 +1,123 @@
+package excel
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// CellMarshaler is implemented by types, that choose the value and style of the cell they are written to, e.g.
+//
+//	func (m Money) MarshalCell() (Cell, error) {
+//		return NewCell(m.Float(), EuroStyle()), nil
+//	}
+type CellMarshaler interface {
+	MarshalCell() (Cell, error)
+}
+
+// CellUnmarshaler is implemented by types, that read themselves from a cell. Empty cells are not passed to UnmarshalCell
+type CellUnmarshaler interface {
+	UnmarshalCell(cell Cell) error
+}
+
+var (
+	cellMarshalerType   = reflect.TypeOf((*CellMarshaler)(nil)).Elem()
+	cellUnmarshalerType = reflect.TypeOf((*CellUnmarshaler)(nil)).Elem()
+)
+
+// marshalCell replaces cell by the cell, that its CellMarshaler value returns. A style or hyperlink set on cell takes precedence.
+// Nil pointers result in an empty value, strings starting with = are written as text unless the marshaler returns a formula cell
+func marshalCell(cell Cell) (Cell, error) {
+	m, ok := cell.Value.(CellMarshaler)
+	if !ok {
+		return cell, nil
+	}
+	if v := reflect.ValueOf(m); v.Kind() == reflect.Ptr && v.IsNil() {
+		cell.Value = nil
+		return cell, nil
+	}
+	marshaled, err := m.MarshalCell()
+	if err != nil {
+		return cell, fmt.Errorf("marshal %T: %w", m, err)
+	}
+	if cell.Style != (Style{}) {
+		marshaled.Style = cell.Style
+	}
+	if cell.Hyperlink != (Hyperlink{}) {
+		marshaled.Hyperlink = cell.Hyperlink
+	}
+	marshaled.coordinates = cell.coordinates
+	marshaled.literal()
+	return marshaled, nil
+}
+
+// marshalerOf returns the CellMarshaler of v, if v or a pointer to v implements it
+func marshalerOf(v reflect.Value) (CellMarshaler, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	if m, ok := v.Interface().(CellMarshaler); ok {
+		return m, true
+	}
+	if !reflect.PtrTo(v.Type()).Implements(cellMarshalerType) {
+		return nil, false
+	}
+	if v.CanAddr() {
+		return v.Addr().Interface().(CellMarshaler), true
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p.Interface().(CellMarshaler), true
+}
+
+// implementsUnmarshaler returns true, if t or a pointer to t implements CellUnmarshaler
+func implementsUnmarshaler(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return reflect.PtrTo(t).Implements(cellUnmarshalerType)
+}
+
+// unmarshalField passes cell to the CellUnmarshaler of fv. Pointers are allocated, empty cells leave fv untouched
+func unmarshalField(fv reflect.Value, cell Cell) error {
+	if !cell.HasValue() {
+		return nil
+	}
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+	if err := fv.Addr().Interface().(CellUnmarshaler).UnmarshalCell(cell); err != nil {
+		return fmt.Errorf("%w: %s", ErrTypeMismatch, err)
+	}
+	return nil
+}
+
+// cellAt returns the cell at coord with its value, formula, style and hyperlink. Empty cells have KindEmpty
+func (sh *Sheet) cellAt(coord Coordinates) (Cell, error) {
+	empty := NewEmptyCell()
+	empty.coordinates = coord
+	if sh.writeAccess {
+		if cell, ok := sh.draft.get(coord); ok {
+			return cell, nil
+		}
+		return empty, nil
+	}
+	axis, err := coord.CellName()
+	if err != nil {
+		return empty, err
+	}
+	raw, err := sh.file.GetCellValue(sh.name, axis, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return empty, sh.cellError(coord, nil, err)
+	}
+	cell, ok, err := sh.loadCell(coord, raw)
+	if err != nil || !ok {
+		return empty, err
+	}
+	cell.coordinates = coord
+	return cell, nil
+}
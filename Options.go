@@ -0,0 +1,52 @@
+package excel
+
+// Logger receives the messages of an Excel file. *slog.Logger satisfies this interface
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// ProgressFunc is called while saving sheet with the number of cells written so far and the total number of cells
+type ProgressFunc func(sheet string, done, total int)
+
+// Options configures logging, progress reporting and saving of an Excel file. The zero value is silent
+type Options struct {
+	Logger   Logger
+	Progress ProgressFunc
+	// Backup keeps the previous file as <path>.<timestamp>.bak when saving over an existing file
+	Backup bool
+}
+
+// SetOptions replaces the options of excel
+func (excel *Excel) SetOptions(opts Options) {
+	excel.options = opts
+}
+
+// Options returns the options of excel
+func (excel *Excel) Options() Options {
+	return excel.options
+}
+
+func (excel *Excel) logger() Logger {
+	if excel == nil || excel.options.Logger == nil {
+		return nopLogger{}
+	}
+	return excel.options.Logger
+}
+
+func (excel *Excel) progress(sheet string, done, total int) {
+	if excel.options.Progress == nil {
+		return
+	}
+	excel.options.Progress(sheet, done, total)
+}
+
+// nopLogger discards every message
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+func (nopLogger) Info(msg string, args ...interface{})  {}
+func (nopLogger) Warn(msg string, args ...interface{})  {}
+func (nopLogger) Error(msg string, args ...interface{}) {}
@@ -0,0 +1,204 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Row is a single row of a sheet. Rows of a draft keep their cells sparse
+type Row struct {
+	sheet  *Sheet
+	index  int
+	cells  []Cell
+	sparse map[int]Cell
+}
+
+// View is a selection of rows below the header of a sheet
+type View struct {
+	sheet *Sheet
+	rows  []Row
+	err   error
+}
+
+// Rows
+
+// Index returns the row number, starting at 1
+func (r Row) Index() int {
+	return r.index
+}
+
+// Cells returns the cells of row up to its last cell, gaps are filled with empty cells
+func (r Row) Cells() []Cell {
+	if r.sparse != nil {
+		return denseCells(r.index, r.sparse)
+	}
+	return r.cells
+}
+
+// Cell returns the cell at column or an empty cell, if row is shorter, column must start at 1
+func (r Row) Cell(column int) Cell {
+	if cell, ok := r.sparse[column]; ok {
+		return cell
+	}
+	if r.sparse != nil || column < 1 || column > len(r.cells) {
+		cell := NewEmptyCell()
+		cell.coordinates = Coordinates{Row: r.index, Column: column}
+		return cell
+	}
+	return r.cells[column-1]
+}
+
+// Value returns the value of the cell at column, column must start at 1
+func (r Row) Value(column int) interface{} {
+	return r.Cell(column).Value
+}
+
+// CellByName returns the cell in the header column with name or an ErrColumnNotFound error
+func (r Row) CellByName(name string) (Cell, error) {
+	column, err := r.sheet.columnIndex(0, name)
+	if err != nil {
+		return Cell{}, err
+	}
+	return r.Cell(column), nil
+}
+
+// Where
+
+// Where returns a view of the rows below the header of sheet, that match fn
+func (sh *Sheet) Where(fn func(Row) bool) *View {
+	view := &View{sheet: sh}
+	rows, err := sh.Rows()
+	if err != nil {
+		view.err = err
+		return view
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if rows.Index() <= sh.lastHeaderRow() {
+			continue
+		}
+		r, err := rows.Row()
+		if err != nil {
+			view.err = err
+			return view
+		}
+		if fn(r) {
+			view.rows = append(view.rows, r)
+		}
+	}
+	view.err = rows.Err()
+	return view
+}
+
+// Where narrows view to the rows, that match fn
+func (v *View) Where(fn func(Row) bool) *View {
+	view := &View{sheet: v.sheet, err: v.err}
+	for _, r := range v.rows {
+		if fn(r) {
+			view.rows = append(view.rows, r)
+		}
+	}
+	return view
+}
+
+// Err returns the error, that occurred while reading the rows of view
+func (v *View) Err() error {
+	return v.err
+}
+
+// Len returns the number of rows in view
+func (v *View) Len() int {
+	return len(v.rows)
+}
+
+// Rows returns the rows of view
+func (v *View) Rows() []Row {
+	return v.rows
+}
+
+// Each calls fn for every row of view
+func (v *View) Each(fn func(Row)) {
+	for _, r := range v.rows {
+		fn(r)
+	}
+}
+
+// CopyTo appends the rows of view with their styles to the draft of sheet
+func (v *View) CopyTo(sheet *Sheet) error {
+	if v.err != nil {
+		return v.err
+	}
+	if !sheet.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sheet.name)
+	}
+	for _, r := range v.rows {
+		sheet.appendCells(r.Cells())
+	}
+	return nil
+}
+
+// Delete deletes the rows of view from the draft of its sheet. Formulas referencing the rows below are shifted up
+func (v *View) Delete() error {
+	if v.err != nil {
+		return v.err
+	}
+	rows := []int{}
+	for _, r := range v.rows {
+		rows = append(rows, r.index)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(rows)))
+	for i := 0; i < len(rows); {
+		// delete consecutive rows at once
+		n := 1
+		for i+n < len(rows) && rows[i+n] == rows[i]-n {
+			n++
+		}
+		if err := v.sheet.DeleteRows(rows[i+n-1], n); err != nil {
+			return err
+		}
+		i += n
+	}
+	v.rows = []Row{}
+	return nil
+}
+
+// Column predicates
+
+// ColumnFunc returns a predicate, that matches rows whose value in the header column with name matches fn.
+// fn receives the same values as a Matcher
+func ColumnFunc(name string, fn func(value interface{}) bool) func(Row) bool {
+	return func(r Row) bool {
+		cell, err := r.CellByName(name)
+		if err != nil {
+			return false
+		}
+		return fn(plainValue(cell))
+	}
+}
+
+// ColumnEquals returns a predicate, that matches rows whose value in the header column with name equals value.
+// Numbers are compared by their value, regardless of their type
+func ColumnEquals(name string, value interface{}) func(Row) bool {
+	return ColumnFunc(name, MatchExact(value))
+}
+
+// ColumnContains returns a predicate, that matches rows whose value in the header column with name contains substr
+func ColumnContains(name, substr string) func(Row) bool {
+	return ColumnFunc(name, MatchContains(substr))
+}
+
+// ColumnMatches returns a predicate, that matches rows whose value in the header column with name matches re
+func ColumnMatches(name string, re *regexp.Regexp) func(Row) bool {
+	return ColumnFunc(name, MatchRegexp(re))
+}
+
+// ColumnBetween returns a predicate, that matches rows whose number in the header column with name is between min and max
+func ColumnBetween(name string, min, max float64) func(Row) bool {
+	return ColumnFunc(name, MatchBetween(min, max))
+}
+
+// ColumnEmpty returns a predicate, that matches rows whose cell in the header column with name is empty
+func ColumnEmpty(name string) func(Row) bool {
+	return ColumnFunc(name, MatchEmpty())
+}
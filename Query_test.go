@@ -0,0 +1,100 @@
+package excel
+
+import (
+	"errors"
+	"testing"
+)
+
+// querySheet returns a sheet with the header Name, Amount and a formula column, that sums up the amounts
+func querySheet(t *testing.T) *Excel {
+	t.Helper()
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	sh.AddHeaderColumn([]string{"Name", "Amount", "Note"})
+	sh.AddRow(map[int]Cell{1: NewCell("a", NoStyle()), 2: NewCell(1, NoStyle()), 3: NewCell("x", NoStyle())})
+	sh.AddRow(map[int]Cell{1: NewCell("b", NoStyle()), 2: NewCell(2, EuroStyle())})
+	sh.AddRow(map[int]Cell{1: NewCell("c", NoStyle()), 2: NewCell(3, NoStyle()), 3: NewCell("x", NoStyle())})
+	sh.AddRow(map[int]Cell{1: NewCell("d", NoStyle()), 2: NewCell(4, NoStyle())})
+	sh.AddRow(map[int]Cell{2: NewFormulaCell("=SUM(B2:B5)", NoStyle())})
+	return excel
+}
+
+func TestWhere(t *testing.T) {
+	excel := querySheet(t)
+	for _, sh := range []*Sheet{excel.Sheet("S"), reopen(t, excel).Sheet("S")} {
+		view := sh.Where(ColumnBetween("Amount", 2, 3))
+		if view.Err() != nil || view.Len() != 2 || view.Rows()[0].Index() != 3 || view.Rows()[1].Index() != 4 {
+			t.Errorf("between: %v, %v", view.Rows(), view.Err())
+		}
+		if view = view.Where(ColumnEmpty("Note")); view.Len() != 1 || view.Rows()[0].Index() != 3 {
+			t.Errorf("narrowed: %v", view.Rows())
+		}
+		names := []interface{}{}
+		sh.Where(ColumnEquals("Note", "x")).Each(func(r Row) {
+			names = append(names, plainValue(r.Cell(1)))
+		})
+		if len(names) != 2 || names[0] != "a" || names[1] != "c" {
+			t.Errorf("equals: %v", names)
+		}
+		if view := sh.Where(ColumnEquals("Unknown", "x")); view.Len() != 0 {
+			t.Errorf("unknown column: %v", view.Rows())
+		}
+	}
+}
+
+func TestViewCopyTo(t *testing.T) {
+	excel := querySheet(t)
+	target := excel.Sheet("T")
+	target.AddHeaderColumn([]string{"Name", "Amount", "Note"})
+	for _, sh := range []*Sheet{excel.Sheet("S"), reopen(t, excel).Sheet("S")} {
+		if err := sh.Where(ColumnEquals("Name", "b")).CopyTo(target); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if target.draft.height != 3 {
+		t.Fatalf("height %d, want 3", target.draft.height)
+	}
+	for row := 2; row <= 3; row++ {
+		cells, _ := target.Row(row)
+		if len(cells) != 2 || plainValue(cells[0]) != "b" || cells[0].Kind() != KindValue {
+			t.Errorf("row %d: %v", row, cells)
+		}
+	}
+	// the draft keeps the style, the read-only sheet its raw style id
+	if cells, _ := target.Row(2); cells[1].Style != EuroStyle() {
+		t.Errorf("row 2 style %v", cells[1].Style)
+	}
+	if cells, _ := target.Row(3); cells[1].Style.Border != -1 || cells[1].Style.Format == 0 {
+		t.Errorf("row 3 style %v, want raw style", cells[1].Style)
+	}
+
+	readOnly := reopen(t, excel).Sheet("T")
+	if err := excel.Sheet("S").Where(ColumnEquals("Name", "a")).CopyTo(readOnly); !errors.Is(err, ErrNoWriteAccess) {
+		t.Errorf("read-only target: got %v, want ErrNoWriteAccess", err)
+	}
+}
+
+func TestViewDelete(t *testing.T) {
+	excel := querySheet(t)
+	sh := excel.Sheet("S")
+	view := sh.Where(func(r Row) bool { return r.Index() != 3 && r.Index() < 6 })
+	if err := view.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if view.Len() != 0 || sh.draft.height != 3 {
+		t.Errorf("view %d rows, sheet height %d", view.Len(), sh.draft.height)
+	}
+	if cell, _ := sh.draft.get(Coordinates{Row: 2, Column: 1}); cell.Value != "b" {
+		t.Errorf("row 2: %v", cell.Value)
+	}
+	if cell, _ := sh.draft.get(Coordinates{Row: 3, Column: 2}); cell.Value.(FormulaCell).Formula != "=SUM(B2:B2)" {
+		t.Errorf("formula %v", cell.Value)
+	}
+
+	if err := reopen(t, excel).Sheet("S").Where(ColumnEquals("Name", "b")).Delete(); !errors.Is(err, ErrNoWriteAccess) {
+		t.Errorf("read-only: got %v, want ErrNoWriteAccess", err)
+	}
+}
@@ -0,0 +1,93 @@
+package excel
+
+import "fmt"
+
+// RowBuilder collects the cells of a new row by header name, e.g.
+//
+//	err := sh.NewRow().Set("Customer", "ACME").SetCell("Amount", NewCell(12.5, EuroStyle())).Add()
+type RowBuilder struct {
+	sheet         *Sheet
+	names         []string
+	cells         map[string]Cell
+	appendColumns bool
+}
+
+// AddRowByName appends a row to the draft and inserts cells in the header columns given by the map.
+// Names, that aren't part of the header, return an ErrColumnNotFound error
+func (sh *Sheet) AddRowByName(cells map[string]Cell) error {
+	row := map[int]Cell{}
+	for name, cell := range cells {
+		column, err := sh.columnIndex(0, name)
+		if err != nil {
+			return err
+		}
+		row[column] = cell
+	}
+	return sh.AppendRow(row)
+}
+
+// NewRow returns a builder for a row, that is appended to the draft of sheet by Add
+func (sh *Sheet) NewRow() *RowBuilder {
+	return &RowBuilder{sheet: sh, cells: map[string]Cell{}}
+}
+
+// Set sets the value of the cell in the header column with name. Strings starting with = are written as text
+func (b *RowBuilder) Set(name string, value interface{}) *RowBuilder {
+	return b.SetStyled(name, value, NoStyle())
+}
+
+// SetStyled sets the value and style of the cell in the header column with name. Strings starting with = are written as text
+func (b *RowBuilder) SetStyled(name string, value interface{}, style Style) *RowBuilder {
+	cell := Cell{Value: value, Style: style}
+	cell.literal()
+	return b.SetCell(name, cell)
+}
+
+// SetCell sets the cell in the header column with name
+func (b *RowBuilder) SetCell(name string, cell Cell) *RowBuilder {
+	if _, ok := b.cells[name]; !ok {
+		b.names = append(b.names, name)
+	}
+	b.cells[name] = cell
+	return b
+}
+
+// AppendColumns appends names, that aren't part of the header, as new header columns on Add
+func (b *RowBuilder) AppendColumns() *RowBuilder {
+	b.appendColumns = true
+	return b
+}
+
+// Add appends the row to the draft of the sheet. Unknown names return an ErrColumnNotFound error, unless AppendColumns is set
+func (b *RowBuilder) Add() error {
+	sh := b.sheet
+	if !sh.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
+	}
+	row := map[int]Cell{}
+	for _, name := range b.names {
+		column, err := sh.columnIndex(0, name)
+		if err != nil && b.appendColumns {
+			column, err = sh.appendColumn(name)
+		}
+		if err != nil {
+			return err
+		}
+		row[column] = b.cells[name]
+	}
+	return sh.AppendRow(row)
+}
+
+// appendColumn adds name as last column to the header of sheet and returns its index. Sheets without rows get a new header
+func (sh *Sheet) appendColumn(name string) (int, error) {
+	if sh.headerRow == 0 {
+		if sh.draft.height > 0 {
+			return 0, fmt.Errorf("%w: can't append column %s to sheet %s", ErrNoHeader, name, sh.name)
+		}
+		return 1, sh.SetHeader([]string{name})
+	}
+	sh.columns = append(append([]string{}, sh.columns...), name)
+	coord := Coordinates{Row: sh.lastHeaderRow(), Column: len(sh.columns)}
+	sh.draft.set(coord, Cell{Value: name, Style: NoStyle()})
+	return coord.Column, nil
+}
@@ -0,0 +1,149 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// RowIterator iterates over the rows of a sheet without loading the whole sheet into memory
+type RowIterator struct {
+	sheet  *Sheet
+	rows   *excelize.Rows
+	index  int
+	values []string
+	cells  []Cell
+	sparse map[int]Cell
+	err    error
+}
+
+// Rows returns an iterator over the rows of sheet, starting at row 1. Sheets without write access are read row by row
+// from the excel file, all others from their draft. Call Next before reading the first row:
+//
+//	rows, err := sh.Rows()
+//	...
+//	defer rows.Close()
+//	for rows.Next() {
+//		values := rows.Values()
+//	}
+//	err = rows.Err()
+func (sh *Sheet) Rows() (*RowIterator, error) {
+	it := &RowIterator{sheet: sh}
+	if sh.writeAccess {
+		return it, nil
+	}
+	rows, err := sh.file.Rows(sh.name)
+	if err != nil {
+		return nil, &SheetError{Sheet: sh.name, Err: err}
+	}
+	it.rows = rows
+	return it, nil
+}
+
+// Next advances the iterator to the next row and returns false, if there are no more rows or an error occurred
+func (it *RowIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.values, it.cells, it.sparse = nil, nil, nil
+	if it.rows == nil {
+		if it.index >= it.sheet.draft.height {
+			return false
+		}
+		it.index++
+		it.sparse = it.sheet.draft.rowCells(it.index)
+		return true
+	}
+	if !it.rows.Next() {
+		if err := it.rows.Error(); err != nil {
+			it.err = &SheetError{Sheet: it.sheet.name, Err: err}
+		}
+		return false
+	}
+	it.index++
+	values, err := it.rows.Columns()
+	if err != nil {
+		it.err = &SheetError{Sheet: it.sheet.name, Err: fmt.Errorf("row %d: %w", it.index, err)}
+		return false
+	}
+	it.values = values
+	return true
+}
+
+// Index returns the number of the current row, starting at 1
+func (it *RowIterator) Index() int {
+	return it.index
+}
+
+// Values returns the values of the current row as text
+func (it *RowIterator) Values() []string {
+	if it.values == nil {
+		it.values = []string{}
+		for _, cell := range denseCells(it.index, it.sparse) {
+			if !cell.HasValue() {
+				it.values = append(it.values, "")
+				continue
+			}
+			it.values = append(it.values, fmt.Sprintf("%v", cell.Value))
+		}
+	}
+	return it.values
+}
+
+// Row returns the current row with its styles. For sheets without write access, the cells are loaded from the excel file
+// on the first call like RequestWriteAccess loads them, with typed values, formulas, styles and hyperlinks
+func (it *RowIterator) Row() (Row, error) {
+	if it.sparse != nil {
+		return Row{sheet: it.sheet, index: it.index, sparse: it.sparse}, nil
+	}
+	if it.cells == nil {
+		cells := []Cell{}
+		for i, value := range it.values {
+			coords := Coordinates{Row: it.index, Column: i + 1}
+			cell, err := it.loadCell(coords, value)
+			if err != nil {
+				return Row{}, err
+			}
+			cells = append(cells, cell)
+		}
+		it.cells = cells
+	}
+	return Row{sheet: it.sheet, index: it.index, cells: it.cells}, nil
+}
+
+// loadCell loads the cell at coords, whose displayed text is value, from the excel file. Cells without value, style
+// and hyperlink are empty cells
+func (it *RowIterator) loadCell(coords Coordinates, value string) (Cell, error) {
+	raw := ""
+	if value != "" {
+		axis, err := coords.CellName()
+		if err != nil {
+			return Cell{}, err
+		}
+		if raw, err = it.sheet.file.GetCellValue(it.sheet.name, axis, excelize.Options{RawCellValue: true}); err != nil {
+			return Cell{}, it.sheet.cellError(coords, value, err)
+		}
+	}
+	cell, ok, err := it.sheet.loadCell(coords, raw)
+	if err != nil {
+		return Cell{}, err
+	}
+	if !ok {
+		cell = NewEmptyCell()
+	}
+	cell.coordinates = coords
+	return cell, nil
+}
+
+// Err returns the error, that stopped the iteration
+func (it *RowIterator) Err() error {
+	return it.err
+}
+
+// Close releases the resources of the iterator
+func (it *RowIterator) Close() error {
+	if it.rows == nil {
+		return nil
+	}
+	return it.rows.Close()
+}
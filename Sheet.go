@@ -4,18 +4,29 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// HeaderSeparator joins the titles of multi-row headers to composite column names
+	HeaderSeparator = " / "
+	// headerDetectionRows is the number of rows DetectHeaderRow scans for the header
+	headerDetectionRows = 20
 )
 
 // Structs
 
 // Sheet wraps the sheets of a excel file into a struct
 type Sheet struct {
+	excel        *Excel
 	file         *excelize.File
 	name         string
 	columns      []string
-	draft        [][]Cell
+	headerRow    int
+	headerRows   int
+	draft        draft
 	writeAccess  bool
 	freezeHeader bool
 }
@@ -25,23 +36,29 @@ type Sheet struct {
 // Sheet retruns the sheet with the given name or creates a new one
 func (excel *Excel) Sheet(name string) *Sheet {
 	// Sheet exists
-	for i, existingSheet := range *excel.sheets {
-		if existingSheet.name == name {
-			return &(*excel.sheets)[i]
-		}
+	if sh, err := excel.SheetByName(name); err == nil {
+		return sh
+	}
+	if err := CheckSheetName(name); err != nil {
+		excel.logger().Warn("creating sheet with invalid name", "sheet", name, "err", err)
 	}
-	fmt.Printf("Creating new sheet %s\n", name)
-	newSheet := Sheet{file: excel.file, name: name, columns: []string{}, draft: [][]Cell{}, writeAccess: true}
-	excel.file.NewSheet(name)
-	*excel.sheets = append(*excel.sheets, newSheet)
-	return &(*excel.sheets)[len(*excel.sheets)-1]
+	excel.logger().Debug("creating new sheet", "sheet", name)
+	newSheet := &Sheet{excel: excel, file: excel.file, name: name, columns: []string{}, writeAccess: true}
+	if _, err := excel.file.NewSheet(name); err != nil {
+		excel.logger().Error("couldn't create sheet", "sheet", name, "err", err)
+	}
+	excel.sheets = append(excel.sheets, newSheet)
+	return newSheet
 }
 
-// ClearSheet clears the content of sheet, but not the draft of sheet
-func (sh *Sheet) clearSheet() {
-	name := sh.name
-	sh.file.DeleteSheet(name)
-	sh.file.NewSheet(name)
+// SheetByName returns the sheet with the given name or an ErrSheetNotFound error
+func (excel *Excel) SheetByName(name string) (*Sheet, error) {
+	for _, existingSheet := range excel.sheets {
+		if existingSheet.name == name {
+			return existingSheet, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSheetNotFound, name)
 }
 
 // Name returns the name of sheet
@@ -49,41 +66,167 @@ func (sh *Sheet) Name() string {
 	return sh.name
 }
 
-// Draft returns a copy of the current draft of sheet
+// Draft returns a copy of the current draft of sheet. Gaps between cells are filled with empty cells
 func (sh *Sheet) Draft() [][]Cell {
-	return sh.draft
+	return sh.draft.dense()
+}
+
+// Cells returns the cells stored in the draft of sheet, ordered by row and column. Unlike Draft, gaps aren't filled
+func (sh *Sheet) Cells() []Cell {
+	cells := make([]Cell, 0, sh.draft.len())
+	sh.draft.each(func(cell Cell) error {
+		cells = append(cells, cell)
+		return nil
+	})
+	return cells
 }
 
 // GetWriteAccess populates draft with current content fo sheet and grants write access
 func (sh *Sheet) GetWriteAccess() {
 	if sh.writeAccess {
-		fmt.Printf("write access for sheet %s already granted\n", sh.name)
+		sh.logger().Debug("write access already granted", "sheet", sh.name)
 		return
 	}
-	sh.draft = [][]Cell{}
-	rows, _ := sh.file.GetRows(sh.name)
-	for i, row := range rows {
-		newCellRow := []Cell{}
-		for j, str := range row {
-			styleID, _ := sh.file.GetCellStyle(sh.name, Coordinates{Row: i + 1, Column: j + 1}.String())
-			newCellRow = append(newCellRow, Cell{Value: str, Style: RawID(styleID)})
-		}
-		sh.draft = append(sh.draft, newCellRow)
+	if err := sh.RequestWriteAccess(); err != nil {
+		sh.logger().Error("couldn't get write access", "sheet", sh.name, "err", err)
+	}
+}
+
+// RequestWriteAccess populates draft with current content of sheet and grants write access.
+// Values are loaded with their types, formulas, hyperlinks and styles. Cells, that aren't changed, are left untouched on save
+func (sh *Sheet) RequestWriteAccess() error {
+	if sh.writeAccess {
+		return nil
+	}
+	newDraft := draft{}
+	rows, err := sh.file.GetRows(sh.name)
+	if err != nil {
+		return &SheetError{Sheet: sh.name, Err: err}
 	}
-	for _, cell := range sh.draft[0] {
-		sh.columns = append(sh.columns, fmt.Sprintf("%s", cell.Value))
+	rawRows, err := sh.file.GetRows(sh.name, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return &SheetError{Sheet: sh.name, Err: err}
+	}
+	for i, row := range rawRows {
+		for j, raw := range row {
+			coords := Coordinates{Row: i + 1, Column: j + 1}
+			cell, ok, err := sh.loadCell(coords, raw)
+			if err != nil {
+				return err
+			}
+			if ok {
+				newDraft.load(coords, cell)
+			}
+		}
 	}
+	newDraft.height = len(rawRows)
+	sh.draft = newDraft
+	sh.loadHeader(rows)
 	sh.writeAccess = true
+	return nil
+}
+
+// loadCell reads the cell at coords with its raw value. Returns false for cells without value, formula, style and hyperlink
+func (sh *Sheet) loadCell(coords Coordinates, raw string) (Cell, bool, error) {
+	axis, err := coords.CellName()
+	if err != nil {
+		return Cell{}, false, err
+	}
+	styleID, err := sh.file.GetCellStyle(sh.name, axis)
+	if err != nil {
+		return Cell{}, false, sh.cellError(coords, nil, err)
+	}
+	formula, err := sh.file.GetCellFormula(sh.name, axis)
+	if err != nil {
+		return Cell{}, false, sh.cellError(coords, nil, err)
+	}
+	hasLink, link, err := sh.file.GetCellHyperLink(sh.name, axis)
+	if err != nil {
+		return Cell{}, false, sh.cellError(coords, nil, err)
+	}
+	cellType, err := sh.file.GetCellType(sh.name, axis)
+	if err != nil {
+		return Cell{}, false, sh.cellError(coords, nil, err)
+	}
+
+	var cell Cell
+	switch {
+	case isErrorCode(formula):
+		cell = NewErrorCell(strings.ToUpper(formula))
+		cell.Style = RawID(styleID)
+	case formula != "":
+		cell = Cell{Value: FormulaCell{Formula: "=" + formula, Result: typedValue(raw, cellType)}, Style: RawID(styleID), kind: KindFormula}
+	case cellType == excelize.CellTypeError:
+		cell = NewErrorCell(raw)
+		cell.Style = RawID(styleID)
+	case raw != "":
+		cell = NewCell(typedValue(raw, cellType), RawID(styleID))
+	case styleID != 0 || hasLink:
+		cell = NewStyledCell(RawID(styleID))
+	default:
+		return Cell{}, false, nil
+	}
+	if hasLink {
+		external := strings.Contains(link, "://") || strings.HasPrefix(strings.ToLower(link), "mailto:")
+		cell.Hyperlink = Hyperlink{Target: link, External: external}
+	}
+	return cell, true, nil
+}
+
+// typedValue converts the raw value of a cell to bool, float64 or time.Time depending on cellType
+func typedValue(raw string, cellType excelize.CellType) interface{} {
+	switch cellType {
+	case excelize.CellTypeBool:
+		return raw == "1" || strings.EqualFold(raw, "true")
+	case excelize.CellTypeDate:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t
+		}
+	case excelize.CellTypeNumber, excelize.CellTypeUnset:
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f
+		}
+	}
+	return raw
+}
+
+// loadHeader sets the first row of rows, that contains a value, as header of sheet. Returns false, if there is none
+func (sh *Sheet) loadHeader(rows [][]string) bool {
+	sh.headerRow = 0
+	sh.headerRows = 0
+	sh.columns = []string{}
+	for i, row := range rows {
+		for _, value := range row {
+			if strings.TrimSpace(value) != "" {
+				sh.headerRow = i + 1
+				sh.headerRows = 1
+				sh.columns = append(sh.columns, row...)
+				return true
+			}
+		}
+	}
+	return false
 }
 
-// FirstSheet returns the first sheet found in the excel file
+// FirstSheet returns the first sheet found in the excel file or nil, if there is none
 func (excel *Excel) FirstSheet() *Sheet {
-	shs := excel.sheets
-	return &(*shs)[0]
+	if len(excel.sheets) == 0 {
+		return nil
+	}
+	return excel.sheets[0]
 }
 
 // ExtractColumnsByName extracts columns by there names from sheet
 func (sh *Sheet) ExtractColumnsByName(columnNames []string) [][]string {
+	data, err := sh.ColumnsByName(columnNames)
+	if err != nil {
+		sh.logger().Error("couldn't extract columns", "sheet", sh.name, "err", err)
+	}
+	return data
+}
+
+// ColumnsByName extracts columns by there names from sheet or returns an ErrColumnNotFound error
+func (sh *Sheet) ColumnsByName(columnNames []string) ([][]string, error) {
 	columns := []string{}
 	columnMap := map[string]int{}
 	for i, name := range sh.columns {
@@ -92,36 +235,60 @@ func (sh *Sheet) ExtractColumnsByName(columnNames []string) [][]string {
 		}
 	}
 	for _, columnName := range columnNames {
-		columnstring, err := excelize.ColumnNumberToName(columnMap[columnName])
+		index, ok := columnMap[columnName]
+		if !ok {
+			return [][]string{}, fmt.Errorf("%w: %s in sheet %s", ErrColumnNotFound, columnName, sh.name)
+		}
+		columnstring, err := excelize.ColumnNumberToName(index)
 		if err != nil {
-			fmt.Printf("error converting index to columnname: %s\n", err)
-			continue
+			return [][]string{}, fmt.Errorf("error converting index to columnname: %w", err)
 		}
 		columns = append(columns, columnstring)
 	}
-	return sh.ExtractColumns(columns)
+	return sh.Columns(columns)
 }
 
 // ExtractColumns returns columns from sheet
 func (sh *Sheet) ExtractColumns(columns []string) [][]string {
-	numeric := []int{}
-	rawData, _ := sh.file.GetRows(sh.name)
-	filteredData := [][]string{}
+	data, err := sh.Columns(columns)
+	if err != nil {
+		sh.logger().Error("couldn't extract columns", "sheet", sh.name, "err", err)
+	}
+	return data
+}
 
+// Columns returns columns from sheet, excluding the header row and the rows above it
+func (sh *Sheet) Columns(columns []string) ([][]string, error) {
+	numeric := []int{}
 	for _, c := range columns {
-		num, _ := excelize.ColumnNameToNumber(c)
+		num, err := excelize.ColumnNameToNumber(c)
+		if err != nil {
+			return [][]string{}, fmt.Errorf("%w: column %s", ErrInvalidCoordinates, c)
+		}
 		numeric = append(numeric, num)
 	}
-	for _, row := range rawData {
+	rows, err := sh.Rows()
+	if err != nil {
+		return [][]string{}, err
+	}
+	defer rows.Close()
+	filteredData := [][]string{}
+	for rows.Next() {
+		if rows.Index() <= sh.lastHeaderRow() {
+			continue
+		}
 		filteredRow := []string{}
-		for j, cell := range row {
+		for j, cell := range rows.Values() {
 			if containsInt(numeric, j+1) {
 				filteredRow = append(filteredRow, cell)
 			}
 		}
 		filteredData = append(filteredData, filteredRow)
 	}
-	return filteredData[1:]
+	if err := rows.Err(); err != nil {
+		return [][]string{}, err
+	}
+	return filteredData, nil
 }
 
 // Modify Sheets
@@ -133,74 +300,103 @@ func (sh *Sheet) NextRow() int {
 
 // CurrentRow returns the current Row
 func (sh *Sheet) CurrentRow() int {
+	row, err := sh.RowCount()
+	if err != nil {
+		sh.logger().Error("couldn't count rows", "sheet", sh.name, "err", err)
+	}
+	return row
+}
+
+// RowCount returns the number of rows in sheet
+func (sh *Sheet) RowCount() (int, error) {
 	if !sh.writeAccess {
-		rows, err := sh.file.GetRows(sh.name)
+		rows, err := sh.Rows()
 		if err != nil {
-			fmt.Println(err)
+			return 0, err
+		}
+		defer rows.Close()
+		for rows.Next() {
 		}
-		return len(rows)
+		return rows.Index(), rows.Err()
 	}
-	return len(sh.draft)
+	return sh.draft.height, nil
 }
 
 // AddHeaderColumn adds a header column to sheet
 func (sh *Sheet) AddHeaderColumn(header []string) {
-	if !sh.writeAccess {
-		fmt.Printf("no permission to write to sheet %s\n", sh.name)
+	if err := sh.SetHeader(header); err != nil {
+		sh.logger().Error("couldn't set header column", "sheet", sh.name, "err", err)
 		return
 	}
+	sh.logger().Debug("set header column", "sheet", sh.name, "columns", sh.columns)
+}
+
+// SetHeader adds or replaces the header column of sheet. A multi-row header is replaced by a single row
+func (sh *Sheet) SetHeader(header []string) error {
+	if !sh.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
+	}
+	if sh.headerRow == 0 {
+		sh.headerRow = 1
+	}
+	sh.headerRows = 1
 
-	headerCells := []Cell{}
+	sh.draft.clearRow(sh.headerRow)
 	for i, h := range header {
-		headerCells = append(headerCells, Cell{Value: h, Style: NoStyle(), coordinates: Coordinates{Column: i + 1, Row: 1}})
+		sh.draft.set(Coordinates{Column: i + 1, Row: sh.headerRow}, Cell{Value: h, Style: NoStyle()})
 	}
-	if len(sh.draft) == 0 {
-		fmt.Println("Writing Header Column:")
-		sh.draft = append(sh.draft, headerCells)
-	} else {
-		fmt.Println("Replacing Header Column:")
-		sh.draft[0] = headerCells
+	if sh.draft.height < sh.headerRow {
+		sh.draft.height = sh.headerRow
 	}
-	sh.columns = header
-	fmt.Println(sh.columns)
+	sh.columns = append([]string{}, header...)
+	return nil
 }
 
 // AddRow scanns for the next available row and inserts cells at the given indexes provided by the map
 func (sh *Sheet) AddRow(columnCellMap map[int]Cell) {
-	if !sh.writeAccess {
-		fmt.Printf("no permission to write to sheet %s\n", sh.name)
-		return
+	if err := sh.AppendRow(columnCellMap); err != nil {
+		sh.logger().Error("couldn't add row", "sheet", sh.name, "err", err)
 	}
+}
 
-	newRowIndexes := []int{}
-	for index := range columnCellMap {
-		newRowIndexes = append(newRowIndexes, index)
+// AppendRow appends a row to the draft and inserts cells at the given indexes provided by the map.
+// Values, that implement CellMarshaler, are replaced by the cell they marshal to
+func (sh *Sheet) AppendRow(columnCellMap map[int]Cell) error {
+	if !sh.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
 	}
-	newRow := []Cell{}
 
-	for i := 1; i != maxInt(newRowIndexes)+1; i++ {
-		if val, ok := columnCellMap[i]; ok {
-			val.coordinates = Coordinates{Column: i, Row: len(sh.draft) + 1}
-			str := strings.TrimSpace(fmt.Sprintf("%s", val.Value))
-			if str == "" {
-				val.Value = StyleCell
-			}
-			newRow = append(newRow, val)
-		} else {
-			newRow = append(newRow, Cell{Value: DraftCell, Style: NoStyle(), coordinates: Coordinates{Column: i, Row: len(sh.draft) + 1}})
+	newRow := map[int]Cell{}
+	for index, val := range columnCellMap {
+		if index < 1 {
+			return fmt.Errorf("%w: column %d, excel starts at 1", ErrInvalidCoordinates, index)
+		}
+		val, err := marshalCell(val)
+		if err != nil {
+			return sh.cellError(Coordinates{Row: sh.draft.height + 1, Column: index}, val.Value, err)
 		}
+		val.blank()
+		newRow[index] = val
 	}
 
-	sh.draft = append(sh.draft, newRow)
+	sh.draft.appendRow(newRow)
+	return nil
 }
 
 // AddEmptyRow adds an empty row at index row
 func (sh *Sheet) AddEmptyRow() {
+	if err := sh.AppendEmptyRow(); err != nil {
+		sh.logger().Error("couldn't add empty row", "sheet", sh.name, "err", err)
+	}
+}
+
+// AppendEmptyRow appends an empty row to the draft
+func (sh *Sheet) AppendEmptyRow() error {
 	if !sh.writeAccess {
-		fmt.Printf("no permission to write to sheet %s\n", sh.name)
-		return
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
 	}
-	sh.draft = append(sh.draft, []Cell{Cell{Value: DraftCell, Style: NoStyle(), coordinates: Coordinates{Column: 1, Row: len(sh.draft) + 1}}})
+	sh.draft.height++
+	return nil
 }
 
 // AddCondition adds a condition, that fills the cell red if its value is less than comparison
@@ -215,42 +411,134 @@ func (sh *Sheet) AddEmptyRow() {
 
 // CopyRow appends row from sheet to the draft of the calling sheet
 func (sh *Sheet) CopyRow(sheet *Sheet, row int) {
-	sh.draft = append(sh.draft, sheet.draft[row])
+	if err := sh.AppendRowFrom(sheet, row+1); err != nil {
+		sh.logger().Error("couldn't copy row", "sheet", sh.name, "err", err)
+	}
+}
+
+// AppendRowFrom appends row from sheet to the draft of the calling sheet, row must start at 1
+func (sh *Sheet) AppendRowFrom(sheet *Sheet, row int) error {
+	if !sh.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
+	}
+	cells, err := sheet.Row(row)
+	if err != nil {
+		return err
+	}
+	sh.appendCells(cells)
+	return nil
+}
+
+// appendCells appends cells as new row to the draft, starting at column 1. Empty cells are skipped
+func (sh *Sheet) appendCells(cells []Cell) {
+	newRow := map[int]Cell{}
+	for i, cell := range cells {
+		if cell.Kind() == KindEmpty {
+			continue
+		}
+		newRow[i+1] = cell
+	}
+	sh.draft.appendRow(newRow)
 }
 
 // GetValue returns the Value from the cell at coord
 func (sh *Sheet) GetValue(coord Coordinates) interface{} {
+	value, err := sh.Value(coord)
+	if err != nil {
+		sh.logger().Error("couldn't get value", "sheet", sh.name, "err", err)
+	}
+	return value
+}
+
+// Value returns the Value from the cell at coord or an ErrInvalidCoordinates error. Empty cells have a nil value
+func (sh *Sheet) Value(coord Coordinates) (interface{}, error) {
+	axis, err := coord.CellName()
+	if err != nil {
+		return nil, err
+	}
 	if !sh.writeAccess {
-		value, err := sh.file.GetCellValue(sh.name, coord.String())
+		value, err := sh.file.GetCellValue(sh.name, axis)
 		if err != nil {
-			fmt.Println(err)
+			return nil, sh.cellError(coord, nil, err)
 		}
-		return value
+		return value, nil
+	}
+	cell, ok := sh.draft.get(coord)
+	if !ok {
+		return nil, nil
 	}
-	fmt.Printf("lenght draft: %d\n", len(sh.draft))
-	return sh.draft[coord.Row-1][coord.Column-1].Value
+	return cell.Value, nil
 }
 
 // GetRow returns row of sheet, row must start at 1
 func (sh *Sheet) GetRow(row int) []Cell {
+	cells, err := sh.Row(row)
+	if err != nil {
+		sh.logger().Error("couldn't get row", "sheet", sh.name, "err", err)
+	}
+	return cells
+}
+
+// Row returns row of sheet, row must start at 1
+func (sh *Sheet) Row(row int) ([]Cell, error) {
 	if row < 1 {
-		fmt.Println("row must start at 1")
-		return []Cell{}
+		return []Cell{}, fmt.Errorf("%w: row %d, row must start at 1", ErrInvalidCoordinates, row)
 	}
 	if !sh.writeAccess {
-		rows, err := sh.file.GetRows(sh.name)
+		rows, err := sh.Rows()
 		if err != nil {
-			fmt.Println(err)
+			return []Cell{}, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			if rows.Index() == row {
+				r, err := rows.Row()
+				return r.Cells(), err
+			}
 		}
-		cells := []Cell{}
-		for i, value := range rows[row] {
-			coords, _ := excelize.CoordinatesToCellName(i, row)
-			styleID, _ := sh.file.GetCellStyle(sh.name, coords)
-			cells = append(cells, Cell{Value: value, Style: RawID(styleID)})
+		if err := rows.Err(); err != nil {
+			return []Cell{}, err
 		}
-		return cells
+		return []Cell{}, fmt.Errorf("%w: row %d is outside of sheet %s", ErrInvalidCoordinates, row, sh.name)
+	}
+	if row > sh.draft.height {
+		return []Cell{}, fmt.Errorf("%w: row %d is outside of the draft of sheet %s", ErrInvalidCoordinates, row, sh.name)
+	}
+	return sh.draft.denseRow(row), nil
+}
+
+// SetCell stores cell at coord in the draft, replacing the cell at coord. Values, that implement CellMarshaler,
+// are replaced by the cell they marshal to
+func (sh *Sheet) SetCell(coord Coordinates, cell Cell) error {
+	if !sh.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
 	}
-	return sh.draft[row-1]
+	if _, err := coord.CellName(); err != nil {
+		return err
+	}
+	cell, err := marshalCell(cell)
+	if err != nil {
+		return sh.cellError(coord, cell.Value, err)
+	}
+	sh.draft.set(coord, cell)
+	return nil
+}
+
+// SetValue sets the value of the cell at coord and keeps its style. The kind of the cell is derived from value
+func (sh *Sheet) SetValue(coord Coordinates, value interface{}) error {
+	cell, _ := sh.draft.get(coord)
+	cell.Value, cell.kind = value, 0
+	return sh.SetCell(coord, cell)
+}
+
+// SetStyle sets the style of the cell at coord and keeps its value
+func (sh *Sheet) SetStyle(coord Coordinates, style Style) error {
+	cell, ok := sh.draft.get(coord)
+	if !ok {
+		cell = NewStyledCell(style)
+	}
+	cell.Style = style
+	return sh.SetCell(coord, cell)
 }
 
 // FreezeHeader freezes the headerrow
@@ -260,8 +548,12 @@ func (sh *Sheet) FreezeHeader() {
 
 // Helper
 
+func (sh *Sheet) logger() Logger {
+	return sh.excel.logger()
+}
+
 func (sh *Sheet) isEmpty() bool {
-	if len(sh.draft) == 0 {
+	if sh.draft.height == 0 {
 		return true
 	}
 	return false
@@ -277,10 +569,13 @@ func PrintHeader(sh *Sheet, startingRow int) {
 		headerTableData := [][]string{}
 		headerTableData = append(headerTableData, []string{strconv.Itoa(k), v})
 		rows, _ := sh.file.GetRows(v)
+		if startingRow >= len(rows) {
+			continue
+		}
 		for index, head := range rows[startingRow] {
-			coordString, err := excelize.CoordinatesToCellName(index, startingRow+1)
+			coordString, err := excelize.CoordinatesToCellName(index+1, startingRow+1)
 			if err != nil {
-				fmt.Println(err)
+				sh.logger().Error("couldn't print header", "sheet", v, "err", err)
 			}
 			headerTableData = append(headerTableData, []string{coordString, head})
 		}
@@ -295,6 +590,120 @@ func (sh *Sheet) HeaderColumns() []string {
 	return sh.columns
 }
 
+// HeaderRow returns the first row of the header of sheet or 0, if sheet has no header
+func (sh *Sheet) HeaderRow() int {
+	return sh.headerRow
+}
+
+// SetHeaderRow declares row as header row of sheet and reads the header columns from it, row must start at 1
+func (sh *Sheet) SetHeaderRow(row int) error {
+	return sh.SetHeaderRows(row, row)
+}
+
+// SetHeaderRows declares the rows from first to last as header of sheet. The header columns are composed of the
+// titles of each row, joined by HeaderSeparator. Empty titles of group rows are filled with the title to their left
+func (sh *Sheet) SetHeaderRows(first, last int) error {
+	if first < 1 || last < first {
+		return fmt.Errorf("%w: header rows %d to %d", ErrInvalidCoordinates, first, last)
+	}
+	rows, err := sh.headerSource()
+	if err != nil {
+		return err
+	}
+	if last > len(rows) {
+		return fmt.Errorf("%w: header row %d is outside of sheet %s", ErrInvalidCoordinates, last, sh.name)
+	}
+	headerRows := rows[first-1 : last]
+	width := 0
+	for _, row := range headerRows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	columns := make([]string, width)
+	for i, row := range headerRows {
+		group := ""
+		for j := 0; j < width; j++ {
+			title := ""
+			if j < len(row) {
+				title = strings.TrimSpace(row[j])
+			}
+			// merged group titles are only stored in their first cell
+			if i < len(headerRows)-1 {
+				if title == "" {
+					title = group
+				}
+				group = title
+			}
+			if title == "" {
+				continue
+			}
+			if columns[j] != "" {
+				columns[j] += HeaderSeparator
+			}
+			columns[j] += title
+		}
+	}
+	sh.headerRow = first
+	sh.headerRows = last - first + 1
+	sh.columns = columns
+	return nil
+}
+
+// DetectHeaderRow declares the row with the most text values among the first rows of sheet as header row.
+// Title blocks above the header usually contain fewer values than the header itself
+func (sh *Sheet) DetectHeaderRow() (int, error) {
+	rows, err := sh.headerSource()
+	if err != nil {
+		return 0, err
+	}
+	best, bestCount := 0, 0
+	for i, row := range rows {
+		if i == headerDetectionRows {
+			break
+		}
+		count := 0
+		for _, value := range row {
+			value = strings.TrimSpace(value)
+			if _, err := strconv.ParseFloat(value, 64); value != "" && err != nil {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = i+1, count
+		}
+	}
+	if best == 0 {
+		return 0, fmt.Errorf("%w: sheet %s", ErrNoHeader, sh.name)
+	}
+	return best, sh.SetHeaderRow(best)
+}
+
+// headerSource returns the values of sheet as strings, taken from the draft if sheet has write access
+func (sh *Sheet) headerSource() ([][]string, error) {
+	rows, err := sh.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	values := [][]string{}
+	for rows.Next() {
+		values = append(values, rows.Values())
+	}
+	return values, rows.Err()
+}
+
+// lastHeaderRow returns the last row of the header of sheet or 0, if sheet has no header
+func (sh *Sheet) lastHeaderRow() int {
+	if sh.headerRow == 0 {
+		return 0
+	}
+	if sh.headerRows < 1 {
+		return sh.headerRow
+	}
+	return sh.headerRow + sh.headerRows - 1
+}
+
 func containsInt(slice []int, value int) bool {
 	for _, i := range slice {
 		if i == value {
@@ -314,10 +723,6 @@ func containsString(slice []string, value string) bool {
 }
 
 func maxInt(slice []int) int {
-	if len(slice) == 0 {
-		fmt.Println("slice is empty")
-		return 0
-	}
 	max := 0
 	for _, i := range slice {
 		if i > max {
@@ -0,0 +1,163 @@
+package excel
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSetValueStyledCell(t *testing.T) {
+	template := excelize.NewFile()
+	styleID, err := template.NewStyle(&excelize.Style{NumFmt: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.SetCellValue("Sheet1", "A1", "a")
+	template.SetCellStyle("Sheet1", "B1", "B1", styleID)
+	template.SetCellValue("Sheet1", "C1", "c")
+	data, err := template.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	excel, err := OpenReader(bytes.NewReader(data.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("Sheet1")
+	if err := sh.RequestWriteAccess(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.SetValue(Coordinates{Row: 1, Column: 2}, 7.0); err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.SetStyle(Coordinates{Row: 1, Column: 4}, IntegerStyle()); err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.SetValue(Coordinates{Row: 1, Column: 4}, 8); err != nil {
+		t.Fatal(err)
+	}
+
+	file := reopen(t, excel).file
+	for axis, want := range map[string]string{"B1": "7", "D1": "8"} {
+		if value, _ := file.GetCellValue("Sheet1", axis, excelize.Options{RawCellValue: true}); value != want {
+			t.Errorf("%s: got %q, want %q", axis, value, want)
+		}
+	}
+	if id, _ := file.GetCellStyle("Sheet1", "B1"); id != styleID {
+		t.Errorf("B1: got style %d, want %d", id, styleID)
+	}
+}
+
+func TestSetValueFormulaCell(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	if err := sh.SetCell(Coordinates{Row: 1, Column: 1}, NewFormulaCell("=1+1", NoStyle())); err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.SetValue(Coordinates{Row: 1, Column: 1}, "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	file := reopen(t, excel).file
+	if formula, _ := file.GetCellFormula("S", "A1"); formula != "" {
+		t.Errorf("got formula %q, want none", formula)
+	}
+	if value, _ := file.GetCellValue("S", "A1"); value != "hello" {
+		t.Errorf("got %q, want hello", value)
+	}
+}
+
+func TestRequestWriteAccessRoundTrip(t *testing.T) {
+	template := excelize.NewFile()
+	date, _ := template.NewStyle(&excelize.Style{NumFmt: 14})
+	amount, _ := template.NewStyle(&excelize.Style{NumFmt: 4})
+	fill, _ := template.NewStyle(&excelize.Style{Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}})
+	template.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount", "Date", "Link", "Note"})
+	template.SetSheetRow("Sheet1", "A2", &[]interface{}{"a", 1.5, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), "site"})
+	template.SetSheetRow("Sheet1", "A3", &[]interface{}{"b", 1234.25, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), "top"})
+	template.SetCellStyle("Sheet1", "B2", "B3", amount)
+	template.SetCellStyle("Sheet1", "C2", "C3", date)
+	template.SetCellStyle("Sheet1", "E2", "E2", fill)
+	template.SetCellHyperLink("Sheet1", "D2", "https://example.com", "External")
+	template.SetCellHyperLink("Sheet1", "D3", "Sheet1!A1", "Location")
+	template.SetCellFormula("Sheet1", "B4", "SUM(B2:B3)")
+	template.SetCellValue("Sheet1", "A5", "merged")
+	template.MergeCell("Sheet1", "A5", "B5")
+	template.SetColWidth("Sheet1", "A", "A", 24)
+	data, err := template.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	excel, err := OpenReader(bytes.NewReader(data.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("Sheet1")
+	if err := sh.RequestWriteAccess(); err != nil {
+		t.Fatal(err)
+	}
+	loaded := map[string]interface{}{}
+	for axis, coords := range map[string]Coordinates{"B2": {Row: 2, Column: 2}, "C2": {Row: 2, Column: 3}, "D2": {Row: 2, Column: 4}, "B4": {Row: 4, Column: 2}} {
+		cell, _ := sh.draft.get(coords)
+		loaded[axis] = cell.Value
+		if axis == "D2" && cell.Hyperlink != (Hyperlink{Target: "https://example.com", External: true}) {
+			t.Errorf("D2: got link %v", cell.Hyperlink)
+		}
+	}
+	if loaded["B2"] != 1.5 || loaded["C2"] != 45322.0 || loaded["D2"] != "site" {
+		t.Errorf("loaded values %v", loaded)
+	}
+	if formula, ok := loaded["B4"].(FormulaCell); !ok || formula.Formula != "=SUM(B2:B3)" {
+		t.Errorf("B4: got %v, want formula", loaded["B4"])
+	}
+	if err := sh.SetValue(Coordinates{Row: 2, Column: 5}, "edited"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "roundtrip.xlsx")
+	if err := excel.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := saved.GetCellValue("Sheet1", "E2"); value != "edited" {
+		t.Errorf("E2: got %q, want edited", value)
+	}
+	if id, _ := saved.GetCellStyle("Sheet1", "E2"); id != fill {
+		t.Errorf("E2: got style %d, want %d", id, fill)
+	}
+	for row := 1; row <= 5; row++ {
+		for column := 1; column <= 5; column++ {
+			axis, _ := Coordinates{Row: row, Column: column}.CellName()
+			if axis == "E2" {
+				continue
+			}
+			wantValue, _ := template.GetCellValue("Sheet1", axis, excelize.Options{RawCellValue: true})
+			gotValue, _ := saved.GetCellValue("Sheet1", axis, excelize.Options{RawCellValue: true})
+			wantFormula, _ := template.GetCellFormula("Sheet1", axis)
+			gotFormula, _ := saved.GetCellFormula("Sheet1", axis)
+			wantStyle, _ := template.GetCellStyle("Sheet1", axis)
+			gotStyle, _ := saved.GetCellStyle("Sheet1", axis)
+			_, wantLink, _ := template.GetCellHyperLink("Sheet1", axis)
+			_, gotLink, _ := saved.GetCellHyperLink("Sheet1", axis)
+			if gotValue != wantValue || gotFormula != wantFormula || gotStyle != wantStyle || gotLink != wantLink {
+				t.Errorf("%s: got %q %q %d %q, want %q %q %d %q", axis, gotValue, gotFormula, gotStyle, gotLink, wantValue, wantFormula, wantStyle, wantLink)
+			}
+		}
+	}
+	if merged, _ := saved.GetMergeCells("Sheet1"); len(merged) != 1 || merged[0].GetStartAxis() != "A5" || merged[0].GetEndAxis() != "B5" {
+		t.Errorf("merged cells: %v", merged)
+	}
+	if width, _ := saved.GetColWidth("Sheet1", "A"); width != 24 {
+		t.Errorf("column width %v, want 24", width)
+	}
+}
@@ -0,0 +1,309 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Visibility describes whether a sheet is shown in excel
+type Visibility int
+
+const (
+	// Visible shows the sheet
+	Visible Visibility = 0
+	// Hidden hides the sheet, it can be unhidden in excel
+	Hidden Visibility = 1
+	// VeryHidden hides the sheet, it can only be unhidden by a macro
+	VeryHidden Visibility = 2
+)
+
+// invalidSheetNameChars can't be part of a sheet name
+const invalidSheetNameChars = `:\/?*[]`
+
+// Sheet Management
+
+// SheetNames returns the names of all sheets of excel in their order
+func (excel *Excel) SheetNames() []string {
+	names := []string{}
+	for _, sheet := range excel.sheets {
+		names = append(names, sheet.name)
+	}
+	return names
+}
+
+// RenameSheet renames the sheet with name oldName to newName. References to the sheet in the formulas of all drafts are renamed as well
+func (excel *Excel) RenameSheet(oldName, newName string) error {
+	sheet, err := excel.SheetByName(oldName)
+	if err != nil {
+		return err
+	}
+	if err := excel.checkNewSheetName(newName, sheet); err != nil {
+		return err
+	}
+	if stream := excel.stream(oldName); stream != nil && !stream.flushed {
+		return fmt.Errorf("%w: sheet %s is streamed, flush it first", ErrNoWriteAccess, oldName)
+	}
+	if err := excel.file.SetSheetName(oldName, newName); err != nil {
+		return &SheetError{Sheet: oldName, Err: err}
+	}
+	rewriteFormulas(excel.sheets, func(_ *Sheet, formula string) string {
+		return mapSheetReferences(formula, oldName, func(ref string) string {
+			return quoteSheetName(newName) + "!" + ref
+		})
+	})
+	sheet.name = newName
+	if stream := excel.stream(oldName); stream != nil {
+		stream.name = newName
+	}
+	return nil
+}
+
+// DeleteSheet deletes the sheet with name. References to the sheet in the formulas of all drafts become #REF!
+func (excel *Excel) DeleteSheet(name string) error {
+	index, err := excel.sheetIndex(name)
+	if err != nil {
+		return err
+	}
+	if excel.sheetVisible(name) && excel.visibleSheets() == 1 {
+		return fmt.Errorf("%w: %s", ErrLastVisibleSheet, name)
+	}
+	if err := excel.file.DeleteSheet(name); err != nil {
+		return &SheetError{Sheet: name, Err: err}
+	}
+	excel.sheets = append(excel.sheets[:index], excel.sheets[index+1:]...)
+	for i, stream := range excel.streams {
+		if stream.name == name {
+			excel.streams = append(excel.streams[:i], excel.streams[i+1:]...)
+			break
+		}
+	}
+	rewriteFormulas(excel.sheets, func(_ *Sheet, formula string) string {
+		return mapSheetReferences(formula, name, func(ref string) string {
+			return "#REF!"
+		})
+	})
+	return nil
+}
+
+// CopySheet copies the sheet with name to a new sheet with name copyName, which is added as last sheet. The draft of a sheet with write access
+// is copied as well
+func (excel *Excel) CopySheet(name, copyName string) (*Sheet, error) {
+	sheet, err := excel.SheetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := excel.checkNewSheetName(copyName, nil); err != nil {
+		return nil, err
+	}
+	if stream := excel.stream(name); stream != nil && !stream.flushed {
+		return nil, fmt.Errorf("%w: sheet %s is streamed, flush it first", ErrNoWriteAccess, name)
+	}
+	source, err := excel.file.GetSheetIndex(name)
+	if err != nil {
+		return nil, &SheetError{Sheet: name, Err: err}
+	}
+	index, err := excel.file.NewSheet(copyName)
+	if err != nil {
+		return nil, &SheetError{Sheet: copyName, Err: err}
+	}
+	if err := excel.file.CopySheet(source, index); err != nil {
+		excel.file.DeleteSheet(copyName)
+		return nil, &SheetError{Sheet: name, Err: err}
+	}
+	newSheet := &Sheet{
+		excel:        excel,
+		file:         excel.file,
+		name:         copyName,
+		columns:      append([]string{}, sheet.columns...),
+		headerRow:    sheet.headerRow,
+		headerRows:   sheet.headerRows,
+		draft:        sheet.draft.clone(),
+		writeAccess:  sheet.writeAccess,
+		freezeHeader: sheet.freezeHeader,
+	}
+	excel.sheets = append(excel.sheets, newSheet)
+	return newSheet, nil
+}
+
+// MoveSheet moves the sheet with name to position, starting at 1. The sheets in between are shifted
+func (excel *Excel) MoveSheet(name string, position int) error {
+	index, err := excel.sheetIndex(name)
+	if err != nil {
+		return err
+	}
+	if position < 1 || position > len(excel.sheets) {
+		return fmt.Errorf("%w: position %d, sheets start at 1 and end at %d", ErrInvalidCoordinates, position, len(excel.sheets))
+	}
+	if index == position-1 {
+		return nil
+	}
+	sheet := excel.sheets[index]
+	sheets := append(append([]*Sheet{}, excel.sheets[:index]...), excel.sheets[index+1:]...)
+	sheets = append(sheets[:position-1], append([]*Sheet{sheet}, sheets[position-1:]...)...)
+	// excelize moves a sheet before its target, so the last position takes a second move
+	if position < len(sheets) {
+		err = excel.file.MoveSheet(name, sheets[position].name)
+	} else if err = excel.file.MoveSheet(name, sheets[position-2].name); err == nil {
+		err = excel.file.MoveSheet(sheets[position-2].name, name)
+	}
+	if err != nil {
+		return &SheetError{Sheet: name, Err: err}
+	}
+	excel.sheets = sheets
+	return nil
+}
+
+// SetSheetVisibility shows or hides the sheet with name. If the active sheet is hidden, the first visible sheet becomes active
+func (excel *Excel) SetSheetVisibility(name string, visibility Visibility) error {
+	index, err := excel.sheetIndex(name)
+	if err != nil {
+		return err
+	}
+	if visibility != Visible && excel.sheetVisible(name) && excel.visibleSheets() == 1 {
+		return fmt.Errorf("%w: %s", ErrLastVisibleSheet, name)
+	}
+	if err := excel.file.SetSheetVisible(name, visibility == Visible, visibility == VeryHidden); err != nil {
+		return &SheetError{Sheet: name, Err: err}
+	}
+	if visibility != Visible && excel.file.GetActiveSheetIndex() == index {
+		for i, sheet := range excel.sheets {
+			if excel.sheetVisible(sheet.name) {
+				excel.file.SetActiveSheet(i)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// SheetVisible returns true, if the sheet with name is visible
+func (excel *Excel) SheetVisible(name string) (bool, error) {
+	if _, err := excel.sheetIndex(name); err != nil {
+		return false, err
+	}
+	visible, err := excel.file.GetSheetVisible(name)
+	if err != nil {
+		return false, &SheetError{Sheet: name, Err: err}
+	}
+	return visible, nil
+}
+
+// SetActiveSheet sets the sheet with name as the sheet, that is shown when the file is opened. Hidden sheets are made visible
+func (excel *Excel) SetActiveSheet(name string) error {
+	index, err := excel.sheetIndex(name)
+	if err != nil {
+		return err
+	}
+	if !excel.sheetVisible(name) {
+		if err := excel.file.SetSheetVisible(name, true); err != nil {
+			return &SheetError{Sheet: name, Err: err}
+		}
+	}
+	excel.file.SetActiveSheet(index)
+	return nil
+}
+
+// ActiveSheet returns the sheet, that is shown when the file is opened, or nil, if there is none
+func (excel *Excel) ActiveSheet() *Sheet {
+	index := excel.file.GetActiveSheetIndex()
+	if index < 0 || index >= len(excel.sheets) {
+		return nil
+	}
+	return excel.sheets[index]
+}
+
+// CheckSheetName returns an ErrInvalidSheetName error, if name can't be used as sheet name in excel
+func CheckSheetName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: name is empty", ErrInvalidSheetName)
+	case utf8.RuneCountInString(name) > 31:
+		return fmt.Errorf("%w: %s is longer than 31 characters", ErrInvalidSheetName, name)
+	case strings.ContainsAny(name, invalidSheetNameChars):
+		return fmt.Errorf("%w: %s contains one of %s", ErrInvalidSheetName, name, invalidSheetNameChars)
+	case strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'"):
+		return fmt.Errorf("%w: %s starts or ends with an apostrophe", ErrInvalidSheetName, name)
+	case strings.EqualFold(name, "History"):
+		return fmt.Errorf("%w: History is reserved by excel", ErrInvalidSheetName)
+	}
+	return nil
+}
+
+// checkNewSheetName validates name and checks, that it isn't used by another sheet than self. Excel compares names case insensitive
+func (excel *Excel) checkNewSheetName(name string, self *Sheet) error {
+	if err := CheckSheetName(name); err != nil {
+		return err
+	}
+	for _, sheet := range excel.sheets {
+		if sheet != self && strings.EqualFold(sheet.name, name) {
+			return fmt.Errorf("%w: %s", ErrSheetExists, name)
+		}
+	}
+	return nil
+}
+
+// sheetIndex returns the position of the sheet with name, starting at 0, or an ErrSheetNotFound error
+func (excel *Excel) sheetIndex(name string) (int, error) {
+	for i, sheet := range excel.sheets {
+		if sheet.name == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrSheetNotFound, name)
+}
+
+// stream returns the stream, that writes the sheet with name, or nil
+func (excel *Excel) stream(name string) *StreamSheet {
+	for _, stream := range excel.streams {
+		if stream.name == name {
+			return stream
+		}
+	}
+	return nil
+}
+
+// visibleSheets returns the number of visible sheets
+func (excel *Excel) visibleSheets() int {
+	count := 0
+	for _, sheet := range excel.sheets {
+		if excel.sheetVisible(sheet.name) {
+			count++
+		}
+	}
+	return count
+}
+
+// sheetVisible returns true, if the sheet with name exists in the excel file and is visible
+func (excel *Excel) sheetVisible(name string) bool {
+	visible, err := excel.file.GetSheetVisible(name)
+	return err == nil && visible
+}
+
+// mapSheetReferences replaces all references in formula, that point to sheet, with the result of fn.
+// fn receives the reference without the sheet prefix
+func mapSheetReferences(formula, sheet string, fn func(ref string) string) string {
+	return mapFormula(formula, func(part string) string {
+		result := strings.Builder{}
+		last := 0
+		for _, m := range referencePattern.FindAllStringSubmatchIndex(part, -1) {
+			if m[2] == -1 || isFunctionName(part, m[0], m[1]) {
+				continue
+			}
+			name := strings.ReplaceAll(strings.Trim(part[m[2]:m[3]], "'"), "''", "'")
+			if !strings.EqualFold(name, sheet) {
+				continue
+			}
+			result.WriteString(part[last:m[0]])
+			result.WriteString(fn(part[m[3]+1 : m[1]]))
+			last = m[1]
+		}
+		result.WriteString(part[last:])
+		return result.String()
+	})
+}
+
+// quoteSheetName returns name as sheet prefix of a reference like 'Sheet 1'
+func quoteSheetName(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
@@ -0,0 +1,61 @@
+package excel
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMoveSheet(t *testing.T) {
+	tests := []struct {
+		name     string
+		position int
+		want     string
+	}{
+		{"A", 3, "B,C,A,D"},
+		{"A", 4, "B,C,D,A"},
+		{"D", 1, "D,A,B,C"},
+		{"C", 2, "A,C,B,D"},
+		{"B", 2, "A,B,C,D"},
+	}
+	for _, tt := range tests {
+		excel, err := Create("A")
+		if err != nil {
+			t.Fatal(err)
+		}
+		excel.Sheet("B")
+		excel.Sheet("C")
+		excel.Sheet("D")
+		if err := excel.SetActiveSheet("C"); err != nil {
+			t.Fatal(err)
+		}
+		if err := excel.MoveSheet(tt.name, tt.position); err != nil {
+			t.Fatalf("MoveSheet(%s, %d): %v", tt.name, tt.position, err)
+		}
+		if got := strings.Join(excel.SheetNames(), ","); got != tt.want {
+			t.Errorf("MoveSheet(%s, %d): sheets %s, want %s", tt.name, tt.position, got, tt.want)
+		}
+		if got := strings.Join(excel.file.GetSheetList(), ","); got != tt.want {
+			t.Errorf("MoveSheet(%s, %d): file sheets %s, want %s", tt.name, tt.position, got, tt.want)
+		}
+		if active := excel.ActiveSheet(); active == nil || active.Name() != "C" {
+			t.Errorf("MoveSheet(%s, %d): active sheet %v, want C", tt.name, tt.position, active)
+		}
+	}
+}
+
+func TestMoveSheetPosition(t *testing.T) {
+	excel, err := Create("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	excel.Sheet("B")
+	for _, position := range []int{0, 3} {
+		if err := excel.MoveSheet("A", position); !errors.Is(err, ErrInvalidCoordinates) {
+			t.Errorf("MoveSheet(A, %d): got %v, want ErrInvalidCoordinates", position, err)
+		}
+	}
+	if err := excel.MoveSheet("X", 1); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("MoveSheet(X, 1): got %v, want ErrSheetNotFound", err)
+	}
+}
@@ -0,0 +1,376 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// referencePattern matches cell references and ranges with an optional sheet prefix like 'Sheet 1'!$A$1:B2,
+// as well as whole columns like A:$C and whole rows like 1:$3
+var referencePattern = regexp.MustCompile(`(?:('(?:[^']|'')+'|[A-Za-z_][A-Za-z0-9_.]*)!)?` +
+	`(?:(\$?)([A-Za-z]{1,3})(\$?)([0-9]+)(?::(\$?)([A-Za-z]{1,3})(\$?)([0-9]+))?` +
+	`|(\$?)([A-Za-z]{1,3}):(\$?)([A-Za-z]{1,3})` +
+	`|(\$?)([0-9]+):(\$?)([0-9]+))`)
+
+// shift describes the insertion or deletion of n rows or columns starting at index at
+type shift struct {
+	rows   bool
+	insert bool
+	at, n  int
+}
+
+// Insert/Delete Rows and Columns
+
+// InsertRows inserts n empty rows before row at. Coordinates of the cells below and formulas referencing them are shifted down
+func (sh *Sheet) InsertRows(at, n int) error {
+	if err := sh.checkShift(at, n, sh.draft.height+1); err != nil {
+		return err
+	}
+	sh.draft.insertRows(at, n)
+	if sh.headerRow >= at {
+		sh.headerRow += n
+	}
+	sh.applyShift(shift{rows: true, insert: true, at: at, n: n})
+	return nil
+}
+
+// DeleteRows deletes n rows starting at row at. Coordinates of the cells below and formulas referencing them are shifted up,
+// references to deleted cells become #REF!
+func (sh *Sheet) DeleteRows(at, n int) error {
+	if err := sh.checkShift(at, n, sh.draft.height); err != nil {
+		return err
+	}
+	if at+n-1 > sh.draft.height {
+		n = sh.draft.height - at + 1
+	}
+	sh.draft.deleteRows(at, n)
+	switch {
+	case sh.headerRow >= at+n:
+		sh.headerRow -= n
+	case sh.headerRow != 0 && sh.lastHeaderRow() >= at:
+		sh.headerRow, sh.headerRows = 0, 0
+		sh.columns = []string{}
+	}
+	sh.applyShift(shift{rows: true, insert: false, at: at, n: n})
+	return nil
+}
+
+// InsertColumns inserts n empty columns before column at. Coordinates of the cells to the right and formulas referencing them are shifted right
+func (sh *Sheet) InsertColumns(at, n int) error {
+	if err := sh.checkShift(at, n, sh.draft.maxWidth()+1); err != nil {
+		return err
+	}
+	sh.draft.insertColumns(at, n)
+	if len(sh.columns) >= at {
+		columns := append(append([]string{}, sh.columns[:at-1]...), make([]string, n)...)
+		sh.columns = append(columns, sh.columns[at-1:]...)
+	}
+	sh.applyShift(shift{rows: false, insert: true, at: at, n: n})
+	return nil
+}
+
+// DeleteColumns deletes n columns starting at column at. Coordinates of the cells to the right and formulas referencing them
+// are shifted left, references to deleted cells become #REF!
+func (sh *Sheet) DeleteColumns(at, n int) error {
+	if err := sh.checkShift(at, n, sh.draft.maxWidth()); err != nil {
+		return err
+	}
+	sh.draft.deleteColumns(at, n)
+	if len(sh.columns) >= at {
+		end := at - 1 + n
+		if end > len(sh.columns) {
+			end = len(sh.columns)
+		}
+		sh.columns = append(append([]string{}, sh.columns[:at-1]...), sh.columns[end:]...)
+	}
+	sh.applyShift(shift{rows: false, insert: false, at: at, n: n})
+	return nil
+}
+
+// checkShift validates the arguments of the insert and delete functions
+func (sh *Sheet) checkShift(at, n, max int) error {
+	if !sh.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
+	}
+	if at < 1 || at > max || n < 1 {
+		return fmt.Errorf("%w: can't shift %d from %d in sheet %s", ErrInvalidCoordinates, n, at, sh.name)
+	}
+	return nil
+}
+
+// applyShift rewrites the formulas of all drafts, that reference sheet
+func (sh *Sheet) applyShift(s shift) {
+	sheets := []*Sheet{sh}
+	if sh.excel != nil {
+		sheets = sh.excel.sheets
+	}
+	rewriteFormulas(sheets, func(other *Sheet, formula string) string {
+		return shiftFormula(formula, sh.name, other.name == sh.name, s)
+	})
+}
+
+// rewriteFormulas replaces the formulas in the drafts of sheets with the result of fn. Changed cells are written on save
+func rewriteFormulas(sheets []*Sheet, fn func(sheet *Sheet, formula string) string) {
+	for _, sheet := range sheets {
+		if !sheet.writeAccess {
+			continue
+		}
+		for _, cells := range sheet.draft.rows {
+			for column, cell := range cells {
+				switch value := cell.Value.(type) {
+				case FormulaCell:
+					formula := fn(sheet, value.Formula)
+					if formula == value.Formula {
+						continue
+					}
+					value.Formula = formula
+					cell.Value = value
+				case *FormulaCell:
+					formula := fn(sheet, value.Formula)
+					if formula == value.Formula {
+						continue
+					}
+					value.Formula = formula
+				case string:
+					if cell.Kind() != KindFormula {
+						continue
+					}
+					formula := fn(sheet, value)
+					if formula == value {
+						continue
+					}
+					cell.Value = formula
+				default:
+					continue
+				}
+				cell.pristine = false
+				cells[column] = cell
+			}
+		}
+	}
+}
+
+// shiftFormula moves the references of formula, that point to sheet. References without a sheet prefix
+// point to sheet, if the formula is part of it
+func shiftFormula(formula, sheet string, local bool, s shift) string {
+	return mapFormula(formula, func(part string) string {
+		return shiftReferences(part, sheet, local, s)
+	})
+}
+
+// offsetFormula moves the relative row references of formula by rows, like excel does when a formula is copied
+func offsetFormula(formula string, rows int) string {
+	return mapFormula(formula, func(part string) string {
+		result := strings.Builder{}
+		last := 0
+		for _, m := range referencePattern.FindAllStringSubmatchIndex(part, -1) {
+			if isFunctionName(part, m[0], m[1]) {
+				continue
+			}
+			ref := strings.Builder{}
+			pos, valid := m[0], true
+			// rows of the first and the optional second reference or the whole rows, that aren't absolute
+			for _, r := range [][]int{m[8:12], m[16:20], m[28:32], m[32:36]} {
+				if r[0] == -1 || r[1] > r[0] {
+					continue
+				}
+				row, err := strconv.Atoi(part[r[2]:r[3]])
+				if err != nil {
+					continue
+				}
+				if row+rows < 1 {
+					valid = false
+					break
+				}
+				ref.WriteString(part[pos:r[2]])
+				ref.WriteString(strconv.Itoa(row + rows))
+				pos = r[3]
+			}
+			result.WriteString(part[last:m[0]])
+			if valid {
+				ref.WriteString(part[pos:m[1]])
+				result.WriteString(ref.String())
+			} else {
+				result.WriteString("#REF!")
+			}
+			last = m[1]
+		}
+		result.WriteString(part[last:])
+		return result.String()
+	})
+}
+
+// mapFormula applies fn to all parts of formula, that aren't string literals
+func mapFormula(formula string, fn func(part string) string) string {
+	result := strings.Builder{}
+	for i, part := range strings.Split(formula, `"`) {
+		if i > 0 {
+			result.WriteString(`"`)
+		}
+		if i%2 == 1 {
+			result.WriteString(part)
+			continue
+		}
+		result.WriteString(fn(part))
+	}
+	return result.String()
+}
+
+// shiftReferences moves the references in a part of a formula without string literals
+func shiftReferences(part, sheet string, local bool, s shift) string {
+	result := strings.Builder{}
+	last := 0
+	for _, m := range referencePattern.FindAllStringSubmatchIndex(part, -1) {
+		start, end := m[0], m[1]
+		if isFunctionName(part, start, end) {
+			continue
+		}
+		prefix := ""
+		if m[2] != -1 {
+			prefix = part[m[2]:m[3]]
+			name := strings.ReplaceAll(strings.Trim(prefix, "'"), "''", "'")
+			if !strings.EqualFold(name, sheet) {
+				continue
+			}
+		} else if !local {
+			continue
+		}
+		result.WriteString(part[last:start])
+		last = end
+		if prefix != "" {
+			prefix += "!"
+		}
+
+		if m[22] != -1 || m[30] != -1 {
+			rows := m[30] != -1
+			g := m[20:28]
+			if rows {
+				g = m[28:36]
+			}
+			from, fromText, ok := s.line(part, g[0:4], -1, rows)
+			to, toText, okTo := s.line(part, g[4:8], 1, rows)
+			if !ok || !okTo || from > to {
+				result.WriteString("#REF!")
+				continue
+			}
+			result.WriteString(prefix + fromText + ":" + toText)
+			continue
+		}
+		from, ok := s.reference(part, m[4:12], -1)
+		if m[12] == -1 {
+			from, ok = s.reference(part, m[4:12], 0)
+			if !ok {
+				result.WriteString("#REF!")
+				continue
+			}
+			result.WriteString(prefix + from.text)
+			continue
+		}
+		to, okTo := s.reference(part, m[12:20], 1)
+		if !ok || !okTo || from.row > to.row || from.column > to.column {
+			result.WriteString("#REF!")
+			continue
+		}
+		result.WriteString(prefix + from.text + ":" + to.text)
+	}
+	result.WriteString(part[last:])
+	return result.String()
+}
+
+// shiftedReference is a single cell reference after shifting
+type shiftedReference struct {
+	row, column int
+	text        string
+}
+
+// reference shifts a single cell reference given by the submatch indexes of $, column, $ and row.
+// edge is -1 for the start of a range, 1 for the end of a range and 0 for single cells
+func (s shift) reference(part string, m []int, edge int) (shiftedReference, bool) {
+	columnAbs, columnName, rowAbs := part[m[0]:m[1]], part[m[2]:m[3]], part[m[4]:m[5]]
+	column, err := excelize.ColumnNameToNumber(columnName)
+	if err != nil {
+		return shiftedReference{}, false
+	}
+	row, err := strconv.Atoi(part[m[6]:m[7]])
+	if err != nil {
+		return shiftedReference{}, false
+	}
+	ok := true
+	if s.rows {
+		row, ok = s.move(row, edge)
+	} else {
+		column, ok = s.move(column, edge)
+	}
+	if !ok || row < 1 || column < 1 {
+		return shiftedReference{}, false
+	}
+	columnName, err = excelize.ColumnNumberToName(column)
+	if err != nil {
+		return shiftedReference{}, false
+	}
+	return shiftedReference{row: row, column: column, text: columnAbs + columnName + rowAbs + strconv.Itoa(row)}, true
+}
+
+// line shifts a whole column or row given by the submatch indexes of $ and column name or row number.
+// It's only moved, if s shifts the same dimension
+func (s shift) line(part string, m []int, edge int, rows bool) (int, string, bool) {
+	abs, text := part[m[0]:m[1]], part[m[2]:m[3]]
+	var p int
+	var err error
+	if rows {
+		p, err = strconv.Atoi(text)
+	} else {
+		p, err = excelize.ColumnNameToNumber(text)
+	}
+	if err != nil {
+		return 0, "", false
+	}
+	if s.rows == rows {
+		ok := true
+		if p, ok = s.move(p, edge); !ok || p < 1 {
+			return 0, "", false
+		}
+	}
+	if rows {
+		return p, abs + strconv.Itoa(p), true
+	}
+	if text, err = excelize.ColumnNumberToName(p); err != nil {
+		return 0, "", false
+	}
+	return p, abs + text, true
+}
+
+// move shifts the index p. Deleted single cells are invalid, deleted range edges are moved to the remaining cells
+func (s shift) move(p int, edge int) (int, bool) {
+	if s.insert {
+		if p >= s.at {
+			return p + s.n, true
+		}
+		return p, true
+	}
+	last := s.at + s.n - 1
+	switch {
+	case p < s.at:
+		return p, true
+	case p > last:
+		return p - s.n, true
+	case edge < 0:
+		return s.at, true
+	case edge > 0:
+		return s.at - 1, true
+	}
+	return 0, false
+}
+
+// isFunctionName returns true, if the match from start to end is a function name like LOG10( or part of another name
+func isFunctionName(part string, start, end int) bool {
+	return start > 0 && isNameChar(part[start-1]) || end < len(part) && (isNameChar(part[end]) || part[end] == '(')
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c == '.' || c == '$' || c == '!' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
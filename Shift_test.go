@@ -0,0 +1,117 @@
+package excel
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShiftFormula(t *testing.T) {
+	insertRows := shift{rows: true, insert: true, at: 3, n: 2}
+	deleteRows := shift{rows: true, insert: false, at: 5, n: 1}
+	insertColumns := shift{rows: false, insert: true, at: 2, n: 1}
+	deleteColumns := shift{rows: false, insert: false, at: 2, n: 1}
+	tests := []struct {
+		formula string
+		s       shift
+		local   bool
+		want    string
+	}{
+		// single cells and ranges
+		{"=A5", insertRows, true, "=A7"},
+		{"=A2", insertRows, true, "=A2"},
+		{"=SUM(A1:A9)", insertRows, true, "=SUM(A1:A11)"},
+		{"=SUM(A4:B9)", insertRows, true, "=SUM(A6:B11)"},
+		{"=SUM(A1:A9)", deleteRows, true, "=SUM(A1:A8)"},
+		{"=SUM(A5:A5)", deleteRows, true, "=SUM(#REF!)"},
+		{"=A5+A6", deleteRows, true, "=#REF!+A5"},
+		{"=C1+A1", insertColumns, true, "=D1+A1"},
+		{"=SUM(A1:C3)", deleteColumns, true, "=SUM(A1:B3)"},
+		{"=B1*2", deleteColumns, true, "=#REF!*2"},
+		// $ anchors are kept, absolute references are shifted as well
+		{"=$A$5+A$5+$A5", insertRows, true, "=$A$7+A$7+$A7"},
+		{"=SUM($B$2:$D$4)", deleteColumns, true, "=SUM($B$2:$C$4)"},
+		// sheet prefixes
+		{"=A5+'Other'!A5", insertRows, true, "=A7+'Other'!A5"},
+		{"='My Sheet'!C3", insertColumns, false, "='My Sheet'!D3"},
+		{"='my sheet'!C3", insertColumns, false, "='my sheet'!D3"},
+		{"=C3", insertColumns, false, "=C3"},
+		{"='It''s'!A5+'My Sheet'!A5", insertRows, false, "='It''s'!A5+'My Sheet'!A7"},
+		// string literals and function names
+		{`=A5&"A5"`, insertRows, true, `=A7&"A5"`},
+		{`=CONCAT("x""A5", A5)`, insertRows, true, `=CONCAT("x""A5", A7)`},
+		{"=LOG10(A5)", deleteRows, true, "=LOG10(#REF!)"},
+		{"=ATAN2(A5,B6)", insertRows, true, "=ATAN2(A7,B8)"},
+		// whole columns and rows
+		{"=SUM(A:A)", insertRows, true, "=SUM(A:A)"},
+		{"=SUM(C:D)", insertColumns, true, "=SUM(D:E)"},
+		{"=SUM(A:C)", insertColumns, true, "=SUM(A:D)"},
+		{"=SUM($A:$C)", deleteColumns, true, "=SUM($A:$B)"},
+		{"=SUM(B:B)", deleteColumns, true, "=SUM(#REF!)"},
+		{"=SUM(5:5)", insertRows, true, "=SUM(7:7)"},
+		{"=SUM(1:$9)", deleteRows, true, "=SUM(1:$8)"},
+		{"=SUM(5:5)", deleteRows, true, "=SUM(#REF!)"},
+		{"=SUM(1:1)", insertColumns, true, "=SUM(1:1)"},
+		{"=SUM('My Sheet'!3:4)", insertRows, false, "=SUM('My Sheet'!5:6)"},
+	}
+	for _, tt := range tests {
+		if got := shiftFormula(tt.formula, "My Sheet", tt.local, tt.s); got != tt.want {
+			t.Errorf("shiftFormula(%s, %+v) = %s, want %s", tt.formula, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestOffsetFormula(t *testing.T) {
+	tests := []struct {
+		formula string
+		rows    int
+		want    string
+	}{
+		{"=A1+B2", 2, "=A3+B4"},
+		{"=$A$1+A$1+$A1", 2, "=$A$1+A$1+$A3"},
+		{"=SUM(A1:A$5)", 1, "=SUM(A2:A$5)"},
+		{"=SUM(1:1)", 3, "=SUM(4:4)"},
+		{"=SUM(A:A)", 3, "=SUM(A:A)"},
+		{`=A2&"A2"`, -1, `=A1&"A2"`},
+		{"=A1", -1, "=#REF!"},
+	}
+	for _, tt := range tests {
+		if got := offsetFormula(tt.formula, tt.rows); got != tt.want {
+			t.Errorf("offsetFormula(%s, %d) = %s, want %s", tt.formula, tt.rows, got, tt.want)
+		}
+	}
+}
+
+func TestShiftColumnsKeepsHeader(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := []string{"A", "B", "C", "D"}
+	sh := excel.Sheet("S")
+	sh.AddHeaderColumn(header)
+	if err := sh.DeleteColumns(2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.InsertColumns(1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(sh.HeaderColumns(), ","); got != ",A,C,D" {
+		t.Errorf("header %q, want \",A,C,D\"", got)
+	}
+
+	ss, err := excel.StreamSheet("T")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.AddHeaderColumn(header); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	streamed, _ := excel.SheetByName("T")
+	streamed.columns[0] = "X"
+	if got := strings.Join(header, ","); got != "A,B,C,D" {
+		t.Errorf("caller's header changed to %q", got)
+	}
+}
@@ -0,0 +1,226 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortKey references a column of a sheet by its index or header name and defines the order of a sort
+type SortKey struct {
+	Column     int
+	Name       string
+	Descending bool
+	// Compare compares text values, e.g. collate.New(language.German).CompareString for locale aware sorting.
+	// Defaults to a case insensitive comparison
+	Compare func(a, b string) int
+}
+
+// SortBy sorts the rows below the header of sheet by keys. Numbers, dates and text are compared by their type,
+// empty cells are always sorted last. Relative row references of moved formulas are adjusted
+func (sh *Sheet) SortBy(keys ...SortKey) error {
+	if !sh.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
+	}
+	columns := make([]int, len(keys))
+	for i, key := range keys {
+		column, err := sh.columnIndex(key.Column, key.Name)
+		if err != nil {
+			return err
+		}
+		columns[i] = column
+	}
+
+	rows := []int{}
+	for row := sh.lastHeaderRow() + 1; row <= sh.draft.height; row++ {
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, key := range keys {
+			a, _ := sh.draft.get(Coordinates{Row: rows[i], Column: columns[k]})
+			b, _ := sh.draft.get(Coordinates{Row: rows[j], Column: columns[k]})
+			if c := compareCells(a, b, key); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	moved := map[int]int{}
+	for i, row := range rows {
+		moved[row] = sh.lastHeaderRow() + 1 + i
+	}
+	sh.draft.move(func(coord Coordinates) (Coordinates, bool) {
+		if row, ok := moved[coord.Row]; ok {
+			coord.Row = row
+		}
+		return coord, true
+	})
+	for from, to := range moved {
+		if from == to {
+			continue
+		}
+		cells := sh.draft.rows[to]
+		for column, cell := range cells {
+			if cell.Kind() != KindFormula {
+				continue
+			}
+			switch value := cell.Value.(type) {
+			case FormulaCell:
+				value.Formula = offsetFormula(value.Formula, to-from)
+				cell.Value = value
+			case *FormulaCell:
+				copied := *value
+				copied.Formula = offsetFormula(copied.Formula, to-from)
+				cell.Value = &copied
+			case string:
+				cell.Value = offsetFormula(value, to-from)
+			}
+			cells[column] = cell
+		}
+	}
+	return nil
+}
+
+// columnIndex returns column, if it's set, or the index of the header column with name
+func (sh *Sheet) columnIndex(column int, name string) (int, error) {
+	if name == "" {
+		if column < 1 {
+			return 0, fmt.Errorf("%w: column %d, excel starts at 1", ErrInvalidCoordinates, column)
+		}
+		return column, nil
+	}
+	for i, title := range sh.columns {
+		if title == name {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s in sheet %s", ErrColumnNotFound, name, sh.name)
+}
+
+// isNumber reports whether value is of a numeric go type
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
+
+// sortRank orders values of different types like excel: numbers, dates, text, booleans and empty cells
+func sortRank(value interface{}) int {
+	if isNumber(value) {
+		return 0
+	}
+	switch value.(type) {
+	case time.Time:
+		return 1
+	case bool:
+		return 3
+	case nil:
+		return 4
+	}
+	return 2
+}
+
+// compareCells compares the values of a and b by key. Empty cells are sorted last, regardless of the order
+func compareCells(a, b Cell, key SortKey) int {
+	va, vb := plainValue(a), plainValue(b)
+	ra, rb := sortRank(va), sortRank(vb)
+	if ra == 4 || rb == 4 {
+		return ra - rb
+	}
+	c := ra - rb
+	if c == 0 {
+		switch x := va.(type) {
+		case time.Time:
+			c = x.Compare(vb.(time.Time))
+		case bool:
+			if x != vb.(bool) {
+				c = 1
+				if !x {
+					c = -1
+				}
+			}
+		case string:
+			if key.Compare != nil {
+				c = key.Compare(x, vb.(string))
+			} else {
+				c = strings.Compare(strings.ToLower(x), strings.ToLower(vb.(string)))
+			}
+		default:
+			fa, fb := toFloat(va), toFloat(vb)
+			switch {
+			case fa < fb:
+				c = -1
+			case fa > fb:
+				c = 1
+			}
+		}
+	}
+	if key.Descending {
+		return -c
+	}
+	return c
+}
+
+// plainValue returns the value of cell, that is used for sorting and filtering. Formulas are represented by their
+// cached result, empty text by nil
+func plainValue(cell Cell) interface{} {
+	if !cell.HasValue() {
+		return nil
+	}
+	value := cell.Value
+	switch f := value.(type) {
+	case FormulaCell:
+		value = f.Result
+	case *FormulaCell:
+		value = f.Result
+	case string:
+		if cell.Kind() == KindFormula {
+			return nil
+		}
+	}
+	if sortRank(value) != 2 {
+		return value
+	}
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Sprintf("%v", value)
+	}
+	if strings.TrimSpace(str) == "" {
+		return nil
+	}
+	return str
+}
+
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package excel
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sortSheet returns a sheet with the header Name, Amount, Note and a row for each entry of rows. Nil values are left empty
+func sortSheet(t *testing.T, rows [][]interface{}) *Sheet {
+	t.Helper()
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	sh.AddHeaderColumn([]string{"Name", "Amount", "Note"})
+	for _, row := range rows {
+		cells := map[int]Cell{}
+		for i, value := range row {
+			if value != nil {
+				cells[i+1] = NewCell(value, NoStyle())
+			}
+		}
+		sh.AddRow(cells)
+	}
+	return sh
+}
+
+func TestSortBy(t *testing.T) {
+	date := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		rows [][]interface{}
+		keys []SortKey
+		want string
+	}{
+		{"numbers", [][]interface{}{{"a", 3}, {"b", 1.5}, {"c", 2}}, []SortKey{{Name: "Amount"}}, "bca"},
+		{"descending", [][]interface{}{{"a", 3}, {"b", 1.5}, {"c", 2}}, []SortKey{{Name: "Amount", Descending: true}}, "acb"},
+		{"column index", [][]interface{}{{"a", 3}, {"b", 1.5}, {"c", 2}}, []SortKey{{Column: 2}}, "bca"},
+		{"multiple keys", [][]interface{}{{"a", 1, "y"}, {"b", 1, "x"}, {"c", 0, "z"}}, []SortKey{{Name: "Amount"}, {Name: "Note"}}, "cba"},
+		{"multiple keys descending", [][]interface{}{{"a", 1, "y"}, {"b", 1, "x"}, {"c", 0, "z"}}, []SortKey{{Name: "Amount"}, {Name: "Note", Descending: true}}, "cab"},
+		{"stable", [][]interface{}{{"a", 1}, {"b", 0}, {"c", 1}, {"d", 0}}, []SortKey{{Name: "Amount"}}, "bdac"},
+		{"text ignores case", [][]interface{}{{"a", nil, "b"}, {"b", nil, "A"}, {"c", nil, "C"}}, []SortKey{{Name: "Note"}}, "bac"},
+		{"text compare", [][]interface{}{{"a", nil, "b"}, {"b", nil, "A"}, {"c", nil, "C"}}, []SortKey{{Name: "Note", Compare: strings.Compare}}, "bca"},
+		{"mixed types", [][]interface{}{{"a", true}, {"b", "x"}, {"c", date}, {"d", 5}, {"e", nil}}, []SortKey{{Name: "Amount"}}, "dcbae"},
+		{"mixed types descending", [][]interface{}{{"a", true}, {"b", "x"}, {"c", date}, {"d", 5}, {"e", nil}}, []SortKey{{Name: "Amount", Descending: true}}, "abcde"},
+		{"empty cells last", [][]interface{}{{"a", nil}, {"b", 2}, {"c", " "}, {"d", 1}}, []SortKey{{Name: "Amount"}}, "dbac"},
+		{"empty cells last descending", [][]interface{}{{"a", nil}, {"b", 2}, {"c", " "}, {"d", 1}}, []SortKey{{Name: "Amount", Descending: true}}, "bdac"},
+	}
+	for _, tt := range tests {
+		sh := sortSheet(t, tt.rows)
+		if err := sh.SortBy(tt.keys...); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := ""
+		for row := 2; row <= sh.draft.height; row++ {
+			cell, _ := sh.draft.get(Coordinates{Row: row, Column: 1})
+			got += cell.Value.(string)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+		if header, _ := sh.draft.get(Coordinates{Row: 1, Column: 1}); header.Value != "Name" || sh.HeaderRow() != 1 {
+			t.Errorf("%s: header moved, row 1 is %v", tt.name, header.Value)
+		}
+	}
+}
+
+func TestSortByFormulas(t *testing.T) {
+	sh := sortSheet(t, [][]interface{}{{"a", 3}, {"b", 1}, {"c", 2}})
+	sh.SetCell(Coordinates{Row: 2, Column: 3}, Cell{Value: "=B2*$B$2+SUM(B$2:B3)"})
+	sh.SetCell(Coordinates{Row: 3, Column: 3}, Cell{Value: FormulaCell{Formula: "=B3*2", Result: 2}})
+	if err := sh.SortBy(SortKey{Name: "Amount"}); err != nil {
+		t.Fatal(err)
+	}
+	if cell, _ := sh.draft.get(Coordinates{Row: 4, Column: 3}); cell.Value != "=B4*$B$2+SUM(B$2:B5)" {
+		t.Errorf("moved formula %v", cell.Value)
+	}
+	if cell, _ := sh.draft.get(Coordinates{Row: 2, Column: 3}); cell.Value != (FormulaCell{Formula: "=B2*2", Result: 2}) {
+		t.Errorf("moved formula cell %v", cell.Value)
+	}
+
+	if err := sh.SortBy(SortKey{Name: "Unknown"}); !errors.Is(err, ErrColumnNotFound) {
+		t.Errorf("unknown column: got %v, want ErrColumnNotFound", err)
+	}
+	if err := reopen(t, sh.excel).Sheet("S").SortBy(SortKey{Column: 1}); !errors.Is(err, ErrNoWriteAccess) {
+		t.Errorf("read-only: got %v, want ErrNoWriteAccess", err)
+	}
+}
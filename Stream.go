@@ -0,0 +1,147 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// StreamSheet writes the rows of a sheet directly to the excel file instead of keeping them in a draft
+type StreamSheet struct {
+	excel   *Excel
+	writer  *excelize.StreamWriter
+	name    string
+	row     int
+	flushed bool
+}
+
+// StreamSheet creates a new sheet with the given name, that is written row by row. Call Flush after the last row
+func (excel *Excel) StreamSheet(name string) (*StreamSheet, error) {
+	if err := excel.checkNewSheetName(name, nil); err != nil {
+		return nil, err
+	}
+	excel.logger().Debug("creating new stream sheet", "sheet", name)
+	if _, err := excel.file.NewSheet(name); err != nil {
+		return nil, &SheetError{Sheet: name, Err: err}
+	}
+	writer, err := excel.file.NewStreamWriter(name)
+	if err != nil {
+		return nil, &SheetError{Sheet: name, Err: err}
+	}
+	excel.sheets = append(excel.sheets, &Sheet{excel: excel, file: excel.file, name: name, columns: []string{}, writeAccess: false})
+	stream := &StreamSheet{excel: excel, writer: writer, name: name}
+	excel.streams = append(excel.streams, stream)
+	return stream, nil
+}
+
+// Name returns the name of sheet
+func (ss *StreamSheet) Name() string {
+	return ss.name
+}
+
+// CurrentRow returns the current Row
+func (ss *StreamSheet) CurrentRow() int {
+	return ss.row
+}
+
+// NextRow returns the next free Row
+func (ss *StreamSheet) NextRow() int {
+	return ss.row + 1
+}
+
+// FreezeHeader freezes the headerrow. It must be called before the first row is added
+func (ss *StreamSheet) FreezeHeader() {
+	if err := ss.writer.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		ss.excel.logger().Error("couldn't freeze header", "sheet", ss.name, "err", err)
+	}
+}
+
+// AddHeaderColumn writes the header column of sheet. It must be written before any other row
+func (ss *StreamSheet) AddHeaderColumn(header []string) error {
+	if ss.row != 0 {
+		return fmt.Errorf("%w: header of sheet %s must be the first row", ErrInvalidCoordinates, ss.name)
+	}
+	headerCells := map[int]Cell{}
+	for i, h := range header {
+		headerCells[i+1] = Cell{Value: h, Style: NoStyle()}
+	}
+	if err := ss.AddRow(headerCells); err != nil {
+		return err
+	}
+	sh, err := ss.excel.SheetByName(ss.name)
+	if err != nil {
+		return err
+	}
+	sh.columns = append([]string{}, header...)
+	sh.headerRow = 1
+	return nil
+}
+
+// AddRow writes a row to sheet and inserts cells at the given indexes provided by the map
+func (ss *StreamSheet) AddRow(columnCellMap map[int]Cell) error {
+	if ss.flushed {
+		return fmt.Errorf("%w: sheet %s has already been flushed", ErrNoWriteAccess, ss.name)
+	}
+	newRowIndexes := []int{}
+	for index := range columnCellMap {
+		if index < 1 {
+			return fmt.Errorf("%w: column %d, excel starts at 1", ErrInvalidCoordinates, index)
+		}
+		newRowIndexes = append(newRowIndexes, index)
+	}
+	values := make([]interface{}, maxInt(newRowIndexes))
+	for index, cell := range columnCellMap {
+		cell, err := marshalCell(cell)
+		if err != nil {
+			return &CellError{Sheet: ss.name, Coordinates: Coordinates{Row: ss.row + 1, Column: index}, Value: cell.Value, Err: err}
+		}
+		cell.blank()
+		if cell.Kind() == KindEmpty {
+			continue
+		}
+		styleID, err := ss.excel.StyleID(cell.Style)
+		if err != nil {
+			return &SheetError{Sheet: ss.name, Err: fmt.Errorf("row %d: %w", ss.row+1, err)}
+		}
+		streamCell := excelize.Cell{StyleID: styleID}
+		switch cell.Kind() {
+		case KindFormula:
+			streamCell.Formula, streamCell.Value = cell.formula()
+		case KindError:
+			streamCell.Formula = fmt.Sprintf("%v", cell.Value)
+		case KindValue:
+			streamCell.Value = cell.Value
+		}
+		values[index-1] = streamCell
+	}
+	axis, err := Coordinates{Row: ss.row + 1, Column: 1}.CellName()
+	if err != nil {
+		return err
+	}
+	if err := ss.writer.SetRow(axis, values); err != nil {
+		return &SheetError{Sheet: ss.name, Err: fmt.Errorf("row %d: %w", ss.row+1, err)}
+	}
+	ss.row++
+	return nil
+}
+
+// AddEmptyRow skips a row of sheet
+func (ss *StreamSheet) AddEmptyRow() error {
+	if ss.flushed {
+		return fmt.Errorf("%w: sheet %s has already been flushed", ErrNoWriteAccess, ss.name)
+	}
+	ss.row++
+	return nil
+}
+
+// Flush ends the stream and writes sheet to the excel file. No rows can be added afterwards
+func (ss *StreamSheet) Flush() error {
+	if ss.flushed {
+		return nil
+	}
+	if err := ss.writer.Flush(); err != nil {
+		return &SheetError{Sheet: ss.name, Err: err}
+	}
+	ss.flushed = true
+	return nil
+}
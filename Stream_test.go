@@ -0,0 +1,58 @@
+package excel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestStreamSheet(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, err := excel.StreamSheet("T")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss.FreezeHeader()
+	if err := ss.AddHeaderColumn([]string{"Name", "Amount", "Total"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.AddRow(map[int]Cell{1: NewCell("a", NoStyle()), 2: NewCell(2.5, EuroStyle()), 3: NewFormulaCell("=B2*2", NoStyle())}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.AddEmptyRow(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.AddRow(map[int]Cell{1: NewCell("b", NoStyle()), 3: NewErrorCell("#N/A")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.AddHeaderColumn([]string{"Name"}); !errors.Is(err, ErrInvalidCoordinates) {
+		t.Errorf("late header: got %v, want ErrInvalidCoordinates", err)
+	}
+	if ss.CurrentRow() != 4 || ss.NextRow() != 5 {
+		t.Errorf("rows: current %d, next %d", ss.CurrentRow(), ss.NextRow())
+	}
+
+	reopened := reopen(t, excel)
+	if err := ss.AddRow(map[int]Cell{1: NewCell("c", NoStyle())}); !errors.Is(err, ErrNoWriteAccess) {
+		t.Errorf("after flush: got %v, want ErrNoWriteAccess", err)
+	}
+	file := reopened.file
+	rows, err := file.GetRows("T", excelize.Options{RawCellValue: true})
+	if err != nil || len(rows) != 4 || rows[1][0] != "a" || rows[1][1] != "2.5" || len(rows[2]) != 0 || rows[3][0] != "b" {
+		t.Errorf("rows %q, %v", rows, err)
+	}
+	if formula, _ := file.GetCellFormula("T", "C2"); formula != "B2*2" {
+		t.Errorf("C2: got formula %q", formula)
+	}
+	if cell, err := reopened.Sheet("T").cellAt(Coordinates{Row: 4, Column: 3}); err != nil || cell.Kind() != KindError {
+		t.Errorf("C4: got %v, %v, want error cell", cell, err)
+	}
+	panes, err := file.GetPanes("T")
+	if err != nil || !panes.Freeze || panes.YSplit != 1 || panes.TopLeftCell != "A2" {
+		t.Errorf("panes %+v, %v", panes, err)
+	}
+}
@@ -0,0 +1,455 @@
+package excel
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// structField describes a field of a struct, that is mapped to a column by its excel tag:
+//
+//	Amount float64 `excel:"Amount,style=euro,order=3,omitempty"`
+//
+// The name defaults to the name of the field, "-" skips the field. Styles are euro, date, integer and none
+type structField struct {
+	name      string
+	index     []int
+	style     Style
+	styled    bool
+	order     int
+	omitEmpty bool
+	unmarshal bool
+	date      bool
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// WriteStructs appends the elements of slice, a slice of structs or struct pointers, as rows to the draft of sheet.
+// Sheets without header get a header of the field names, otherwise the fields are matched with the header columns
+// by name and missing columns are appended
+func (sh *Sheet) WriteStructs(slice interface{}) error {
+	if !sh.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, sh.name)
+	}
+	v := reflect.ValueOf(slice)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Errorf("%w: expected a slice of structs, got %T", ErrTypeMismatch, slice)
+	}
+	fields, err := structFields(v.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	columns, err := sh.structColumns(fields, true)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		cells, err := structCells(v.Index(i), fields, columns)
+		if err != nil {
+			return &SheetError{Sheet: sh.name, Err: fmt.Errorf("row %d: %w", sh.draft.height+1, err)}
+		}
+		if err := sh.AppendRow(cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadStructs reads the rows below the header of sheet into dst, a pointer to a slice of structs or struct pointers.
+// Header columns are matched with the fields by name, fields without column are left empty and rows without values
+// are skipped. Cells, that can't be converted, are returned as CellErrors after all rows have been read
+func (sh *Sheet) ReadStructs(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: expected a pointer to a slice of structs, got %T", ErrTypeMismatch, dst)
+	}
+	slice := v.Elem()
+	fields, err := structFields(slice.Type().Elem())
+	if err != nil {
+		return err
+	}
+	columns, err := sh.structColumns(fields, false)
+	if err != nil {
+		return err
+	}
+
+	rows, err := sh.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	date1904 := sh.date1904()
+	result := reflect.MakeSlice(slice.Type(), 0, 0)
+	errs := CellErrors{}
+	for rows.Next() {
+		if rows.Index() <= sh.lastHeaderRow() || isEmptyRow(rows.Values()) {
+			continue
+		}
+		elem, rowErrs := sh.readStruct(rows.Index(), slice.Type().Elem(), fields, columns, date1904)
+		errs = append(errs, rowErrs...)
+		result = reflect.Append(result, elem)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	slice.Set(result)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// structColumns returns the header column of each field. Sheets without header and rows get a header of the field names.
+// With appendMissing, fields without column are appended to the header, otherwise their column is 0
+func (sh *Sheet) structColumns(fields []structField, appendMissing bool) ([]int, error) {
+	columns := make([]int, len(fields))
+	if len(sh.columns) == 0 {
+		if !appendMissing || sh.draft.height > 0 || !sh.writeAccess {
+			return nil, fmt.Errorf("%w: sheet %s", ErrNoHeader, sh.name)
+		}
+		header := []string{}
+		for i, field := range fields {
+			header = append(header, field.name)
+			columns[i] = i + 1
+		}
+		return columns, sh.SetHeader(header)
+	}
+	for i, field := range fields {
+		column, err := sh.columnIndex(0, field.name)
+		if err != nil && appendMissing {
+			column, err = sh.appendColumn(field.name)
+		}
+		if err != nil && appendMissing {
+			return nil, err
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+// structCells returns the cells of the struct or struct pointer v by column
+func structCells(v reflect.Value, fields []structField, columns []int) (map[int]Cell, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return map[int]Cell{}, nil
+		}
+		v = v.Elem()
+	}
+	cells := map[int]Cell{}
+	for i, field := range fields {
+		cell, ok, err := field.cell(v)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.name, err)
+		}
+		if ok {
+			cells[columns[i]] = cell
+		}
+	}
+	return cells, nil
+}
+
+// readStruct reads row into a new value of t, a struct or a pointer to a struct, and returns the errors of its cells
+func (sh *Sheet) readStruct(row int, t reflect.Type, fields []structField, columns []int, date1904 bool) (reflect.Value, CellErrors) {
+	structType := t
+	if t.Kind() == reflect.Ptr {
+		structType = t.Elem()
+	}
+	elem := reflect.New(structType).Elem()
+	errs := CellErrors{}
+	for i, field := range fields {
+		if columns[i] == 0 {
+			continue
+		}
+		coord := Coordinates{Row: row, Column: columns[i]}
+		fv, ok := field.value(elem, true)
+		if !ok {
+			continue
+		}
+		var value interface{}
+		var err error
+		if field.unmarshal {
+			var cell Cell
+			if cell, err = sh.cellAt(coord); err == nil {
+				value = cell.Value
+				err = unmarshalField(fv, cell)
+			}
+		} else if value, err = sh.plainValueAt(coord); err == nil && field.date {
+			value, err = sh.dateValue(coord, value, date1904)
+		}
+		if err == nil && !field.unmarshal {
+			err = setField(fv, value)
+		}
+		if err != nil {
+			errs = append(errs, &CellError{Sheet: sh.name, Coordinates: coord, Header: field.name, Value: value, Err: err})
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Coordinates.Column < errs[j].Coordinates.Column
+	})
+	if t.Kind() == reflect.Ptr {
+		return elem.Addr(), errs
+	}
+	return elem, errs
+}
+
+// structFields returns the mapped fields of t, a struct or a pointer to a struct, ordered by their order option.
+// Fields without order follow in their declaration order. Fields of embedded structs are promoted
+func structFields(t reflect.Type) ([]structField, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: expected a struct, got %s", ErrTypeMismatch, t)
+	}
+	fields := []structField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("excel")
+		if tag == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && !tagged && ft.Kind() == reflect.Struct && ft != timeType {
+			embedded, err := structFields(ft)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range embedded {
+				e.index = append([]int{i}, e.index...)
+				fields = append(fields, e)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		field, err := parseStructTag(f.Name, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		field.index = []int{i}
+		field.unmarshal = implementsUnmarshaler(f.Type)
+		field.date = ft == timeType
+		if !field.styled && field.date {
+			field.style, field.styled = DateStyle(), true
+		}
+		fields = append(fields, field)
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		a, b := fields[i].order, fields[j].order
+		return a != 0 && (b == 0 || a < b)
+	})
+	return fields, nil
+}
+
+// parseStructTag parses an excel tag like "Amount,style=euro,order=3,omitempty"
+func parseStructTag(fieldName, tag string) (structField, error) {
+	field := structField{name: fieldName}
+	options := strings.Split(tag, ",")
+	if options[0] != "" {
+		field.name = options[0]
+	}
+	for _, option := range options[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "omitempty":
+			field.omitEmpty = true
+		case "order":
+			order, err := strconv.Atoi(value)
+			if err != nil || order < 1 {
+				return field, fmt.Errorf("invalid order %s, order must start at 1", value)
+			}
+			field.order = order
+		case "style":
+			style, ok := map[string]Style{"euro": EuroStyle(), "date": DateStyle(), "integer": IntegerStyle(), "none": NoStyle()}[value]
+			if !ok {
+				return field, fmt.Errorf("unknown style %s", value)
+			}
+			field.style, field.styled = style, true
+		case "":
+		default:
+			return field, fmt.Errorf("unknown option %s", key)
+		}
+	}
+	return field, nil
+}
+
+// value returns the field of the struct v or false, if it's part of a nil embedded struct.
+// With alloc, nil embedded structs are allocated instead
+func (f structField) value(v reflect.Value, alloc bool) (reflect.Value, bool) {
+	for i, index := range f.index {
+		if i > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					if !alloc || !v.CanSet() {
+						return reflect.Value{}, false
+					}
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(index)
+	}
+	return v, true
+}
+
+// cell returns the cell for the field of the struct v or false, if the field is nil or empty and omitted.
+// Fields, that implement CellMarshaler, choose their value and style, unless the tag sets a style
+func (f structField) cell(v reflect.Value) (Cell, bool, error) {
+	fv, ok := f.value(v, false)
+	if !ok {
+		return Cell{}, false, nil
+	}
+	if f.omitEmpty && fv.IsZero() {
+		return Cell{}, false, nil
+	}
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return Cell{}, false, nil
+		}
+		fv = fv.Elem()
+	}
+	style := NoStyle()
+	if f.styled {
+		style = f.style
+	}
+	if m, ok := marshalerOf(fv); ok {
+		cell, err := marshalCell(Cell{Value: m, Style: style})
+		return cell, err == nil, err
+	}
+	// excel can't represent dates before 1900
+	if fv.Type() == timeType && fv.IsZero() {
+		return NewStyledCell(style), true, nil
+	}
+	cell := Cell{Value: plainField(fv), Style: style}
+	cell.literal()
+	return cell, true, nil
+}
+
+// plainField converts the value of a field to a value, that can be written to a cell.
+// Named types are converted to their underlying type, unknown types are written as text
+func plainField(v reflect.Value) interface{} {
+	if v.Type() == timeType && v.CanInterface() {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	if v.CanInterface() {
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// setField converts the plain value of a cell and stores it in fv. Pointers are allocated, empty cells and values, that can't
+// be converted, leave fv untouched
+func setField(fv reflect.Value, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	if !fv.CanSet() {
+		return fmt.Errorf("%w: field of type %s can't be set", ErrTypeMismatch, fv.Type())
+	}
+	if fv.Kind() == reflect.Ptr {
+		elem := reflect.New(fv.Type().Elem())
+		if err := setField(elem.Elem(), value); err != nil {
+			return err
+		}
+		fv.Set(elem)
+		return nil
+	}
+	if fv.Type() == timeType {
+		t, err := timeValue(value)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(stringValue(value))); err != nil {
+			return fmt.Errorf("%w: %s", ErrTypeMismatch, err)
+		}
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(stringValue(value))
+	case reflect.Bool:
+		b, err := boolValue(value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := intValue(value)
+		if err != nil {
+			return err
+		}
+		if fv.OverflowInt(i) {
+			return mismatch(value, fv.Type().String())
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := intValue(value)
+		if err != nil {
+			return err
+		}
+		if i < 0 || fv.OverflowUint(uint64(i)) {
+			return mismatch(value, fv.Type().String())
+		}
+		fv.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		f, err := floatValue(value)
+		if err != nil {
+			return err
+		}
+		if fv.OverflowFloat(f) {
+			return mismatch(value, fv.Type().String())
+		}
+		fv.SetFloat(f)
+	case reflect.Interface:
+		if fv.NumMethod() > 0 {
+			return fmt.Errorf("%w: unsupported field type %s", ErrTypeMismatch, fv.Type())
+		}
+		fv.Set(reflect.ValueOf(value))
+	default:
+		return fmt.Errorf("%w: unsupported field type %s", ErrTypeMismatch, fv.Type())
+	}
+	return nil
+}
+
+// isEmptyRow returns true, if values contains only empty text
+func isEmptyRow(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
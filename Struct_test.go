@@ -0,0 +1,272 @@
+package excel
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type structBase struct {
+	ID int `excel:"ID,order=1"`
+}
+
+type structStatus string
+
+type structInvoice struct {
+	*structBase
+	Customer string
+	Amount   float64 `excel:"Amount,style=euro,order=2"`
+	Date     time.Time
+	Due      *time.Time
+	Note     *string      `excel:",omitempty"`
+	Status   structStatus `excel:"State"`
+	internal int
+	Skipped  int `excel:"-"`
+}
+
+func TestParseStructTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want structField
+		err  bool
+	}{
+		{"", structField{name: "Field"}, false},
+		{"Name", structField{name: "Name"}, false},
+		{",omitempty", structField{name: "Field", omitEmpty: true}, false},
+		{"Amount,style=euro,order=3", structField{name: "Amount", style: EuroStyle(), styled: true, order: 3}, false},
+		{"Date, style=date", structField{name: "Date", style: DateStyle(), styled: true}, false},
+		{",style=none", structField{name: "Field", style: NoStyle(), styled: true}, false},
+		{",style=bold", structField{}, true},
+		{",order=0", structField{}, true},
+		{",order=x", structField{}, true},
+		{",unknown", structField{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseStructTag("Field", tt.tag)
+		if tt.err {
+			if err == nil {
+				t.Errorf("parseStructTag(%q): expected error", tt.tag)
+			}
+			continue
+		}
+		if err != nil || got.name != tt.want.name || got.style != tt.want.style || got.styled != tt.want.styled ||
+			got.order != tt.want.order || got.omitEmpty != tt.want.omitEmpty {
+			t.Errorf("parseStructTag(%q) = %+v, %v, want %+v", tt.tag, got, err, tt.want)
+		}
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	fields, err := structFields(reflect.TypeOf(&structInvoice{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, field := range fields {
+		names = append(names, field.name)
+	}
+	if got := strings.Join(names, ","); got != "ID,Amount,Customer,Date,Due,Note,State" {
+		t.Errorf("fields %s", got)
+	}
+	if !fields[3].date || fields[3].style != DateStyle() || !fields[4].date {
+		t.Errorf("time fields: %+v, %+v", fields[3], fields[4])
+	}
+	if _, err := structFields(reflect.TypeOf(1)); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("int: got %v, want ErrTypeMismatch", err)
+	}
+	if _, err := structFields(reflect.TypeOf(struct {
+		A int `excel:",order=-1"`
+	}{})); err == nil {
+		t.Error("invalid tag: expected error")
+	}
+}
+
+func TestWriteStructs(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	note := "paid"
+	due := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	invoices := []*structInvoice{
+		{structBase: &structBase{ID: 7}, Customer: "A", Amount: 2.5, Date: due, Due: &due, Note: &note, Status: "open"},
+		{Customer: "B"},
+		nil,
+	}
+	if err := sh.WriteStructs(invoices); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(sh.HeaderColumns(), ","); got != "ID,Amount,Customer,Date,Due,Note,State" {
+		t.Fatalf("header %s", got)
+	}
+	row, err := sh.Row(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{int64(7), 2.5, "A", due, due, "paid", "open"}
+	for i, value := range want {
+		if row[i].Value != value {
+			t.Errorf("row 2, column %d: %v, want %v", i+1, row[i].Value, value)
+		}
+	}
+	if row[1].Style != EuroStyle() || row[3].Style != DateStyle() || row[2].Style != NoStyle() {
+		t.Errorf("row 2 styles: %v, %v, %v", row[1].Style, row[2].Style, row[3].Style)
+	}
+	// nil embedded structs and pointers are left empty, zero times keep their style
+	row, _ = sh.Row(3)
+	if row[0].HasValue() || row[4].HasValue() || row[5].HasValue() || row[3].Kind() != KindStyled || row[2].Value != "B" {
+		t.Errorf("row 3: %v", row)
+	}
+	if sh.draft.height != 4 {
+		t.Errorf("height %d, nil element should be written as empty row", sh.draft.height)
+	}
+	// missing columns are appended to the header
+	if err := sh.WriteStructs([]struct{ Customer, Extra string }{{"C", "x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := sh.HeaderColumns(); len(got) != 8 || got[7] != "Extra" {
+		t.Errorf("header %v", got)
+	}
+	if err := sh.WriteStructs(1); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("int: got %v, want ErrTypeMismatch", err)
+	}
+}
+
+type structCode string
+
+func (c structCode) MarshalCell() (Cell, error) {
+	return Cell{Value: string(c)}, nil
+}
+
+func TestWriteStructsText(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	rows := []struct {
+		Note string
+		Code structCode
+	}{{`=HYPERLINK("http://example.com","x")`, "== heading =="}}
+	if err := sh.WriteStructs(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.NewRow().Set("Note", "=1+1").SetStyled("Code", "=2+2", EuroStyle()).Add(); err != nil {
+		t.Fatal(err)
+	}
+	// cells built by the caller keep the formula heuristic
+	if err := sh.AppendRow(map[int]Cell{1: {Value: "=3+3"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	file := reopen(t, excel).file
+	for axis, want := range map[string]string{"A2": rows[0].Note, "B2": "== heading ==", "A3": "=1+1", "B3": "=2+2"} {
+		if formula, _ := file.GetCellFormula("S", axis); formula != "" {
+			t.Errorf("%s: got formula %q, want text", axis, formula)
+		}
+		if value, _ := file.GetCellValue("S", axis); value != want {
+			t.Errorf("%s: got %q, want %q", axis, value, want)
+		}
+	}
+	if formula, _ := file.GetCellFormula("S", "A4"); formula != "3+3" {
+		t.Errorf("A4: got formula %q, want 3+3", formula)
+	}
+}
+
+type structLevel int
+
+func (l *structLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+// RecordBase is exported, because embedded pointers to unexported structs can't be allocated
+type RecordBase struct {
+	ID int
+}
+
+type structRecord struct {
+	*RecordBase
+	Name    string
+	Date    *time.Time
+	Level   structLevel
+	Amount  *big.Rat
+	Paid    bool
+	Ignored string `excel:"-"`
+}
+
+func TestReadStructs(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	if err := sh.SetHeader([]string{"ID", "Name", "Date", "Level", "Amount", "Paid", "Ignored"}); err != nil {
+		t.Fatal(err)
+	}
+	sh.AddRow(map[int]Cell{1: NewCell(1, NoStyle()), 2: NewCell("a", NoStyle()), 3: NewCell(45000.5, DateStyle()),
+		4: NewCell("low", NoStyle()), 5: NewCell("1.5", NoStyle()), 6: NewCell(true, NoStyle()), 7: NewCell("x", NoStyle())})
+	sh.AddEmptyRow()
+	sh.AddRow(map[int]Cell{1: NewCell(2.5, NoStyle()), 2: NewCell("b", NoStyle()), 3: NewCell(45000, NoStyle()), 4: NewCell("mid", NoStyle())})
+
+	for _, sheet := range []*Sheet{sh, reopen(t, excel).Sheet("S")} {
+		var records []*structRecord
+		err := sheet.ReadStructs(&records)
+		var errs CellErrors
+		if !errors.As(err, &errs) || !errors.Is(err, ErrTypeMismatch) {
+			t.Fatalf("write access %v: got %v, want CellErrors", sheet.writeAccess, err)
+		}
+		want := []struct {
+			coord  Coordinates
+			header string
+		}{{Coordinates{Row: 4, Column: 1}, "ID"}, {Coordinates{Row: 4, Column: 3}, "Date"}, {Coordinates{Row: 4, Column: 4}, "Level"}}
+		if len(errs) != len(want) {
+			t.Fatalf("write access %v: %v", sheet.writeAccess, errs)
+		}
+		for i, w := range want {
+			if errs[i].Sheet != "S" || errs[i].Coordinates != w.coord || errs[i].Header != w.header || errs[i].Value == nil {
+				t.Errorf("write access %v, error %d: %+v, want %v %s", sheet.writeAccess, i, errs[i], w.coord, w.header)
+			}
+		}
+		// the empty row is skipped, convertible fields of rows with errors are set
+		if len(records) != 2 {
+			t.Fatalf("write access %v: %d records", sheet.writeAccess, len(records))
+		}
+		first, second := records[0], records[1]
+		if first.ID != 1 || first.Name != "a" || first.Level != 1 || first.Amount.RatString() != "3/2" || !first.Paid || first.Ignored != "" {
+			t.Errorf("write access %v, record 1: %+v", sheet.writeAccess, first)
+		}
+		if first.Date == nil || first.Date.Format("2006-01-02 15:04") != "2023-03-15 12:00" {
+			t.Errorf("write access %v, record 1 date: %v", sheet.writeAccess, first.Date)
+		}
+		if second.Name != "b" || second.Date != nil || second.Amount != nil || second.Paid {
+			t.Errorf("write access %v, record 2: %+v", sheet.writeAccess, second)
+		}
+	}
+}
+
+func TestReadStructsErrors(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh := excel.Sheet("S")
+	var records []structRecord
+	if err := sh.ReadStructs(&records); !errors.Is(err, ErrNoHeader) {
+		t.Errorf("without header: got %v, want ErrNoHeader", err)
+	}
+	if err := sh.ReadStructs(records); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("slice: got %v, want ErrTypeMismatch", err)
+	}
+}
@@ -1,6 +1,10 @@
 package excel
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
 
 // Constants
 
@@ -41,46 +45,38 @@ type BorderID int
 // FormatID represents the formatting of the cell
 type FormatID int
 
-// encode structs to string
-
-func (s Style) string() string {
-	st := ""
+// encode structs to excelize styles
 
+func (s Style) excelizeStyle() *excelize.Style {
+	// style has been initialized with a raw id, use RawID() instead
 	if ok, _ := s.RawID(); ok {
-		fmt.Println("style has been initialized with a raw id, use RawID() instead")
-		return st
+		return nil
 	}
 
 	if s.Border == NoBorder && s.Format == NoFormat {
-		return st
+		return nil
 	}
 
+	st := &excelize.Style{}
 	switch s.Border {
-	case NoBorder:
-		st += `{`
 	case Top:
-		st += `{"border":[{"type":"top","color":"000000","style":1}]`
+		st.Border = []excelize.Border{{Type: "top", Color: "000000", Style: 1}}
 	case Left:
-		st += `{"border":[{"type":"left","color":"000000","style":1}]`
+		st.Border = []excelize.Border{{Type: "left", Color: "000000", Style: 1}}
 	case Right:
-		st += `{"border":[{"type":"right","color":"000000","style":1}]`
+		st.Border = []excelize.Border{{Type: "right", Color: "000000", Style: 1}}
 	case LeftRight:
-		st += `{"border":[{"type":"left","color":"000000","style":1}, {"type":"right","color":"000000","style":1}]`
-	}
-
-	if s.Border != NoBorder && s.Format != NoFormat {
-		st += `,`
+		st.Border = []excelize.Border{{Type: "left", Color: "000000", Style: 1}, {Type: "right", Color: "000000", Style: 1}}
 	}
 
 	switch s.Format {
-	case NoFormat:
-		st += `}`
 	case Date:
-		st += `"number_format": 17}`
+		st.NumFmt = 17
 	case Integer:
-		st += `"number_format": 0}`
+		st.NumFmt = 0
 	case Euro:
-		st += `"custom_number_format": "#,##0.00\\ [$\u20AC-1]"}`
+		euro := "#,##0.00\\ [$\u20AC-1]"
+		st.CustomNumFmt = &euro
 	}
 	return st
 }
@@ -93,6 +89,29 @@ func (s *Style) RawID() (bool, int) {
 	return false, 0
 }
 
+// StyleID returns the id of style in the excel file. Each distinct style is registered only once
+func (excel *Excel) StyleID(style Style) (int, error) {
+	if isRaw, id := style.RawID(); isRaw {
+		return id, nil
+	}
+	excelizeStyle := style.excelizeStyle()
+	if excelizeStyle == nil {
+		return 0, nil
+	}
+	if id, ok := excel.styles[style]; ok {
+		return id, nil
+	}
+	id, err := excel.file.NewStyle(excelizeStyle)
+	if err != nil {
+		return 0, fmt.Errorf("style %+v: %w", style, err)
+	}
+	if excel.styles == nil {
+		excel.styles = map[Style]int{}
+	}
+	excel.styles[style] = id
+	return id, nil
+}
+
 // Convenience
 
 // RawID returns a Style struct with the provided styleID
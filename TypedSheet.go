@@ -0,0 +1,137 @@
+package excel
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TypedSheet maps the rows below the header of a sheet to values of T, a struct or a pointer to a struct,
+// by the excel tags of its fields. Rows are indexed from 0, starting with the first row below the header
+type TypedSheet[T any] struct {
+	sheet  *Sheet
+	typ    reflect.Type
+	fields []structField
+}
+
+// NewTypedSheet returns a typed view of sheet
+func NewTypedSheet[T any](sheet *Sheet) (*TypedSheet[T], error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	fields, err := structFields(typ)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedSheet[T]{sheet: sheet, typ: typ, fields: fields}, nil
+}
+
+// Sheet returns the underlying sheet
+func (ts *TypedSheet[T]) Sheet() *Sheet {
+	return ts.sheet
+}
+
+// Len returns the number of rows below the header
+func (ts *TypedSheet[T]) Len() int {
+	count, err := ts.sheet.RowCount()
+	if err != nil {
+		ts.sheet.logger().Error("couldn't count rows", "sheet", ts.sheet.name, "err", err)
+	}
+	if count < ts.sheet.lastHeaderRow() {
+		return 0
+	}
+	return count - ts.sheet.lastHeaderRow()
+}
+
+// Append appends value as new row to the draft of the sheet. Values, that implement Insertable, insert themselves
+func (ts *TypedSheet[T]) Append(value T) error {
+	if !ts.sheet.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, ts.sheet.name)
+	}
+	if data, ok := interface{}(value).(Insertable); ok {
+		ts.sheet.Add(data)
+		return nil
+	}
+	if data, ok := interface{}(&value).(Insertable); ok {
+		ts.sheet.Add(data)
+		return nil
+	}
+	return ts.sheet.WriteStructs([]T{value})
+}
+
+// All returns the values of all rows below the header, the value at index i is the one At(i) returns.
+// Empty rows result in empty values, cells, that can't be converted, are returned as CellErrors after all rows have been read
+func (ts *TypedSheet[T]) All() ([]T, error) {
+	values := []T{}
+	columns, err := ts.sheet.structColumns(ts.fields, false)
+	if err != nil {
+		return values, err
+	}
+	date1904 := ts.sheet.date1904()
+	errs := CellErrors{}
+	for i, n := 0, ts.Len(); i < n; i++ {
+		value, rowErrs := ts.sheet.readStruct(ts.sheet.lastHeaderRow()+1+i, ts.typ, ts.fields, columns, date1904)
+		errs = append(errs, rowErrs...)
+		values = append(values, value.Interface().(T))
+	}
+	if len(errs) > 0 {
+		return values, errs
+	}
+	return values, nil
+}
+
+// At returns the value of row i. Cells are converted like ReadStructs does, empty rows result in an empty value
+func (ts *TypedSheet[T]) At(i int) (T, error) {
+	var zero T
+	if err := ts.checkIndex(i); err != nil {
+		return zero, err
+	}
+	columns, err := ts.sheet.structColumns(ts.fields, false)
+	if err != nil {
+		return zero, err
+	}
+	value, errs := ts.sheet.readStruct(ts.sheet.lastHeaderRow()+1+i, ts.typ, ts.fields, columns, ts.sheet.date1904())
+	if len(errs) > 0 {
+		return value.Interface().(T), errs
+	}
+	return value.Interface().(T), nil
+}
+
+// Update replaces the cells of row i with the fields of value. Fields without header column are appended to the header
+func (ts *TypedSheet[T]) Update(i int, value T) error {
+	if !ts.sheet.writeAccess {
+		return fmt.Errorf("%w: sheet %s", ErrNoWriteAccess, ts.sheet.name)
+	}
+	if err := ts.checkIndex(i); err != nil {
+		return err
+	}
+	columns, err := ts.sheet.structColumns(ts.fields, true)
+	if err != nil {
+		return err
+	}
+	row := ts.sheet.lastHeaderRow() + 1 + i
+	cells, err := structCells(reflect.ValueOf(&value).Elem(), ts.fields, columns)
+	if err != nil {
+		return &SheetError{Sheet: ts.sheet.name, Err: fmt.Errorf("row %d: %w", row, err)}
+	}
+	for _, column := range columns {
+		coord := Coordinates{Row: row, Column: column}
+		cell, ok := cells[column]
+		if !ok {
+			if _, exists := ts.sheet.draft.get(coord); !exists {
+				continue
+			}
+			cell = NewEmptyCell()
+		}
+		cell.blank()
+		if err := ts.sheet.SetCell(coord, cell); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkIndex returns an ErrInvalidCoordinates error, if there is no row i
+func (ts *TypedSheet[T]) checkIndex(i int) error {
+	if n := ts.Len(); i < 0 || i >= n {
+		return fmt.Errorf("%w: row %d of %d in sheet %s", ErrInvalidCoordinates, i, n, ts.sheet.name)
+	}
+	return nil
+}
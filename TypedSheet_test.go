@@ -0,0 +1,63 @@
+package excel
+
+import (
+	"errors"
+	"testing"
+)
+
+type typedItem struct {
+	Name  string
+	Count int
+}
+
+func TestTypedSheet(t *testing.T) {
+	excel, err := Create("S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, err := NewTypedSheet[typedItem](excel.Sheet("S"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.Append(typedItem{"a", 1}); err != nil {
+		t.Fatal(err)
+	}
+	ts.Sheet().AddEmptyRow()
+	if err := ts.Append(typedItem{"b", 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewTypedSheet[typedItem](reopen(t, excel).Sheet("S"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []typedItem{{"a", 1}, {}, {"b", 2}}
+	for _, sheet := range []*TypedSheet[typedItem]{ts, reopened} {
+		if sheet.Len() != len(want) {
+			t.Fatalf("len %d, want %d", sheet.Len(), len(want))
+		}
+		all, err := sheet.All()
+		if err != nil || len(all) != len(want) {
+			t.Fatalf("all: %v, %v", all, err)
+		}
+		for i, value := range want {
+			at, err := sheet.At(i)
+			if err != nil || at != value || all[i] != value {
+				t.Errorf("row %d: At %v, %v, All %v, want %v", i, at, err, all[i], value)
+			}
+		}
+		if _, err := sheet.At(len(want)); !errors.Is(err, ErrInvalidCoordinates) {
+			t.Errorf("at %d: got %v, want ErrInvalidCoordinates", len(want), err)
+		}
+	}
+
+	if err := ts.Update(1, typedItem{"c", 3}); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := ts.At(1); err != nil || value != (typedItem{"c", 3}) {
+		t.Errorf("updated row: %v, %v", value, err)
+	}
+	if err := reopened.Update(0, typedItem{}); !errors.Is(err, ErrNoWriteAccess) {
+		t.Errorf("update read-only: got %v, want ErrNoWriteAccess", err)
+	}
+}